@@ -0,0 +1,244 @@
+package set
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// The binary format consists of a version byte, a layout byte, the number of
+// elements as uvarint, and the elements in the given layout.
+const binaryVersion = 1
+
+const (
+	layoutCodec = iota // elements encoded one after another by a Codec
+	layoutInt          // sorted signed integers: varint first element, then uvarint deltas
+	layoutUint         // sorted unsigned integers: uvarint first element, then uvarint deltas
+	layoutGob          // gob encoded slice of elements
+)
+
+// ErrInvalidBinary is returned if binary data cannot be decoded.
+var ErrInvalidBinary = errors.New("set: invalid binary data")
+
+// Codec encodes and decodes single elements for the binary format.
+type Codec[T any] interface {
+	// AppendElement appends the encoding of elem to b and returns the extended buffer.
+	AppendElement(b []byte, elem T) ([]byte, error)
+
+	// DecodeElement decodes an element from the beginning of b.
+	// Returns the element and the number of bytes read.
+	DecodeElement(b []byte) (T, int, error)
+}
+
+// stringCodec encodes elements whose underlying type is string as uvarint length and bytes.
+type stringCodec[T any] struct{}
+
+func (stringCodec[T]) AppendElement(b []byte, elem T) ([]byte, error) {
+	str := reflect.ValueOf(elem).String()
+	b = binary.AppendUvarint(b, uint64(len(str)))
+	return append(b, str...), nil
+}
+
+func (stringCodec[T]) DecodeElement(b []byte) (T, int, error) {
+	var elem T
+	l, n := binary.Uvarint(b)
+	if n <= 0 || l > uint64(len(b)-n) {
+		return elem, 0, ErrInvalidBinary
+	}
+	reflect.ValueOf(&elem).Elem().SetString(string(b[n : n+int(l)]))
+	return elem, n + int(l), nil
+}
+
+// binaryLayout returns the layout that is used for T if no Codec is given.
+func binaryLayout[T any]() int {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return layoutInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return layoutUint
+	case reflect.String:
+		return layoutCodec
+	}
+	return layoutGob
+}
+
+// AppendBinary appends the binary encoding of s to b and returns the extended buffer.
+// If c is nil, integers are encoded as sorted deltas, strings with their length,
+// and all other types with [encoding/gob]. Otherwise c is used for all elements.
+func AppendBinary[T any](b []byte, s ReadOnlySet[T], c Codec[T]) ([]byte, error) {
+	layout := layoutCodec
+	if c == nil {
+		layout = binaryLayout[T]()
+		c = stringCodec[T]{}
+	}
+	b = append(b, binaryVersion, byte(layout))
+	b = binary.AppendUvarint(b, uint64(s.Cardinality()))
+	switch layout {
+	case layoutInt:
+		vals := make([]int64, 0, s.Cardinality())
+		for elem := range s.Iter() {
+			vals = append(vals, reflect.ValueOf(elem).Int())
+		}
+		slices.Sort(vals)
+		for i, v := range vals {
+			if i == 0 {
+				b = binary.AppendVarint(b, v)
+			} else {
+				b = binary.AppendUvarint(b, uint64(v-vals[i-1]))
+			}
+		}
+	case layoutUint:
+		vals := make([]uint64, 0, s.Cardinality())
+		for elem := range s.Iter() {
+			vals = append(vals, reflect.ValueOf(elem).Uint())
+		}
+		slices.Sort(vals)
+		for i, v := range vals {
+			if i == 0 {
+				b = binary.AppendUvarint(b, v)
+			} else {
+				b = binary.AppendUvarint(b, v-vals[i-1])
+			}
+		}
+	case layoutGob:
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(s.Elements()); err != nil {
+			return nil, err
+		}
+		b = append(b, buf.Bytes()...)
+	default:
+		var err error
+		for elem := range s.Iter() {
+			if b, err = c.AppendElement(b, elem); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+// DecodeBinary decodes data that was encoded by [AppendBinary] and adds the elements to s.
+// Codec c must be the same that was used for encoding.
+func DecodeBinary[T any](data []byte, s Set[T], c Codec[T]) error {
+	if len(data) < 2 || data[0] != binaryVersion {
+		return fmt.Errorf("%w: unknown version", ErrInvalidBinary)
+	}
+	layout := int(data[1])
+	if c == nil {
+		if layout != binaryLayout[T]() {
+			return fmt.Errorf("%w: layout %d does not match element type", ErrInvalidBinary, layout)
+		}
+		c = stringCodec[T]{}
+	} else if layout != layoutCodec {
+		return fmt.Errorf("%w: data was not encoded with a codec", ErrInvalidBinary)
+	}
+	count, n := binary.Uvarint(data[2:])
+	if n <= 0 {
+		return fmt.Errorf("%w: invalid count", ErrInvalidBinary)
+	}
+	pos := 2 + n
+	if layout == layoutGob {
+		var elems []T
+		if err := gob.NewDecoder(bytes.NewReader(data[pos:])).Decode(&elems); err != nil {
+			return err
+		}
+		if uint64(len(elems)) != count {
+			return fmt.Errorf("%w: got %d elements, want %d", ErrInvalidBinary, len(elems), count)
+		}
+		s.Update(elems...)
+		return nil
+	}
+	var prev uint64
+	for i := uint64(0); i < count; i++ {
+		var elem T
+		switch layout {
+		case layoutInt, layoutUint:
+			var v uint64
+			if i == 0 && layout == layoutInt {
+				var x int64
+				x, n = binary.Varint(data[pos:])
+				v = uint64(x)
+			} else {
+				v, n = binary.Uvarint(data[pos:])
+				if i > 0 {
+					v += prev
+				}
+			}
+			if n <= 0 {
+				return fmt.Errorf("%w: invalid integer at offset %d", ErrInvalidBinary, pos)
+			}
+			prev = v
+			rv := reflect.ValueOf(&elem).Elem()
+			if layout == layoutInt {
+				if rv.OverflowInt(int64(v)) {
+					return fmt.Errorf("%w: integer overflow at offset %d", ErrInvalidBinary, pos)
+				}
+				rv.SetInt(int64(v))
+			} else {
+				if rv.OverflowUint(v) {
+					return fmt.Errorf("%w: integer overflow at offset %d", ErrInvalidBinary, pos)
+				}
+				rv.SetUint(v)
+			}
+		default:
+			var err error
+			if elem, n, err = c.DecodeElement(data[pos:]); err != nil {
+				return fmt.Errorf("element at offset %d: %w", pos, err)
+			}
+		}
+		pos += n
+		s.Add(elem)
+	}
+	if pos != len(data) {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidBinary, len(data)-pos)
+	}
+	return nil
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface (see [AppendBinary]).
+func (s MapSet[T]) MarshalBinary() ([]byte, error) {
+	return AppendBinary[T](nil, &s, nil)
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+// The elements of the Set are replaced by the decoded elements.
+func (s *MapSet[T]) UnmarshalBinary(data []byte) error {
+	s.Clear()
+	return DecodeBinary[T](data, s, nil)
+}
+
+// GobEncode implements the [gob.GobEncoder] interface.
+func (s MapSet[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements the [gob.GobDecoder] interface.
+func (s *MapSet[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface (see [AppendBinary]).
+func (s TreeSet[T]) MarshalBinary() ([]byte, error) {
+	return AppendBinary[T](nil, &s, nil)
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+// The elements of the Set are replaced by the decoded elements.
+func (s *TreeSet[T]) UnmarshalBinary(data []byte) error {
+	s.Clear()
+	return DecodeBinary[T](data, s, nil)
+}
+
+// GobEncode implements the [gob.GobEncoder] interface.
+func (s TreeSet[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements the [gob.GobDecoder] interface.
+func (s *TreeSet[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
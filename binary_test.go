@@ -0,0 +1,177 @@
+package set
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	type id int16
+	type point struct{ X, Y int }
+	check := func(name string, got, want any) {
+		t.Helper()
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+
+	ints := NewMapSet(5, -3, math.MaxInt64, math.MinInt64, 0)
+	data, err := ints.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ints2 TreeSet[int]
+	if err := ints2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	check("int", ints2.Elements(), []int{math.MinInt64, -3, 0, 5, math.MaxInt64})
+
+	uints := NewTreeSet[uint8](255, 0, 7)
+	data, _ = uints.MarshalBinary()
+	var uints2 MapSet[uint8]
+	if err := uints2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	check("uint8", uints2.data, map[uint8]struct{}{0: {}, 7: {}, 255: {}})
+
+	ids := NewTreeSet[id](3, 1)
+	data, _ = ids.MarshalBinary()
+	ids2 := NewTreeSet[id](9)
+	if err := ids2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	check("id", ids2.Elements(), []id{1, 3})
+
+	strs := NewTreeSet("", "b", "äöü")
+	data, _ = strs.MarshalBinary()
+	var strs2 TreeSet[string]
+	if err := strs2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	check("string", strs2.Elements(), []string{"", "b", "äöü"})
+
+	points := NewMapSet(point{1, 2}, point{3, 4})
+	data, _ = points.MarshalBinary()
+	var points2 MapSet[point]
+	if err := points2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	check("point", points2.data, points.data)
+}
+
+func TestBinaryCompact(t *testing.T) {
+	s := NewTreeSet[int]()
+	for i := range 1000 {
+		s.Add(1_000_000 + 2*i)
+	}
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > 1010 {
+		t.Errorf("got %d bytes, want at most 1010", len(data))
+	}
+}
+
+func TestGob(t *testing.T) {
+	type record struct {
+		Tags *MapSet[string]
+		IDs  *TreeSet[int]
+	}
+	var buf bytes.Buffer
+	in := record{NewMapSet("a", "b"), NewTreeSet(2, 1)}
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Tags.Equal(in.Tags) || !out.IDs.Equal(in.IDs) {
+		t.Errorf("got %v and %v, want %v and %v", out.Tags, out.IDs, in.Tags, in.IDs)
+	}
+}
+
+func TestGobValue(t *testing.T) {
+	type record struct {
+		Tags MapSet[string]
+		IDs  TreeSet[int]
+	}
+	var in record
+	in.Tags.Update("a", "b")
+	in.IDs.Update(2, 1)
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Tags.Equal(&in.Tags) || !out.IDs.Equal(&in.IDs) {
+		t.Errorf("got %v and %v, want %v and %v", &out.Tags, &out.IDs, &in.Tags, &in.IDs)
+	}
+	if _, err := (MapSet[int]{}).MarshalBinary(); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+}
+
+type fixedCodec struct{}
+
+func (fixedCodec) AppendElement(b []byte, elem uint32) ([]byte, error) {
+	return binary.BigEndian.AppendUint32(b, elem), nil
+}
+
+func (fixedCodec) DecodeElement(b []byte) (uint32, int, error) {
+	if len(b) < 4 {
+		return 0, 0, ErrInvalidBinary
+	}
+	return binary.BigEndian.Uint32(b), 4, nil
+}
+
+func TestBinaryCodec(t *testing.T) {
+	s := NewTreeSet[uint32](1, 2)
+	data, err := AppendBinary[uint32](nil, s, fixedCodec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 3+8 {
+		t.Errorf("got %d bytes, want 11", len(data))
+	}
+	s2 := NewTreeSet[uint32]()
+	if err := DecodeBinary[uint32](data, s2, fixedCodec{}); err != nil {
+		t.Fatal(err)
+	}
+	if !s2.Equal(s) {
+		t.Errorf("got %v, want %v", s2, s)
+	}
+	if err := DecodeBinary[uint32](data, s2, nil); !errors.Is(err, ErrInvalidBinary) {
+		t.Errorf("got %v, want %v", err, ErrInvalidBinary)
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	data, _ := NewTreeSet(1, 300).MarshalBinary()
+	var tests = [][]byte{
+		nil,
+		{2, layoutInt, 0},
+		{binaryVersion, layoutUint, 0},
+		data[:len(data)-1],
+		append(data, 0),
+	}
+	for i, test := range tests {
+		var s MapSet[int]
+		if err := s.UnmarshalBinary(test); !errors.Is(err, ErrInvalidBinary) {
+			t.Errorf("%d: got %v, want %v", i, err, ErrInvalidBinary)
+		}
+	}
+	var s MapSet[int8]
+	if err := s.UnmarshalBinary(data); !errors.Is(err, ErrInvalidBinary) {
+		t.Errorf("got %v, want %v", err, ErrInvalidBinary)
+	}
+}
@@ -0,0 +1,20 @@
+package set
+
+// countCommon counts the elements that a and b have in common by looking up
+// the elements of the smaller set in the larger one.
+// If limit > 0 it stops after limit elements.
+func countCommon[T any](a, b ReadOnlySet[T], limit int) int {
+	if b.Cardinality() < a.Cardinality() {
+		a, b = b, a
+	}
+	n := 0
+	for elem := range a.Iter() {
+		if b.Contains(elem) {
+			n++
+			if n == limit {
+				break
+			}
+		}
+	}
+	return n
+}
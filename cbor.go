@@ -0,0 +1,244 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// cborTagSet is the CBOR tag for a mathematical finite set.
+const cborTagSet = 258
+
+// CBOR major types.
+const (
+	cborUint   = 0
+	cborNegInt = 1
+	cborText   = 3
+	cborArray  = 4
+	cborTag    = 6
+	cborSimple = 7
+)
+
+// appendCBORHead appends the head of a data item with the given major type and argument n.
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), n)
+}
+
+// readCBORHead reads the head of a data item. Returns the major type, the additional
+// information, the argument and the size of the head. For indefinite lengths
+// (additional information 31) the argument is 0.
+func readCBORHead(b []byte) (major, info byte, n uint64, size int, err error) {
+	if len(b) == 0 {
+		return 0, 0, 0, 0, errTruncated
+	}
+	major, info = b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		return major, info, uint64(info), 1, nil
+	case info <= 27:
+		size = 1 << (info - 24)
+		var ok bool
+		if n, ok = readUint(b[1:], size); !ok {
+			return 0, 0, 0, 0, errTruncated
+		}
+		return major, info, n, 1 + size, nil
+	case info == 31:
+		return major, info, 0, 1, nil
+	}
+	return 0, 0, 0, 0, fmt.Errorf("%w: invalid CBOR additional information %d", ErrInvalidBinary, info)
+}
+
+// cborCodec is the builtin Codec for CBOR. It supports elements whose
+// underlying type is an integer, floating-point, string or bool type.
+type cborCodec[T any] struct{}
+
+func (cborCodec[T]) AppendElement(b []byte, elem T) ([]byte, error) {
+	rv := reflect.ValueOf(elem)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := rv.Int()
+		if v < 0 {
+			return appendCBORHead(b, cborNegInt, uint64(^v)), nil
+		}
+		return appendCBORHead(b, cborUint, uint64(v)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendCBORHead(b, cborUint, rv.Uint()), nil
+	case reflect.Float32:
+		return binary.BigEndian.AppendUint32(append(b, 0xfa), math.Float32bits(float32(rv.Float()))), nil
+	case reflect.Float64:
+		return binary.BigEndian.AppendUint64(append(b, 0xfb), math.Float64bits(rv.Float())), nil
+	case reflect.String:
+		str := rv.String()
+		return append(appendCBORHead(b, cborText, uint64(len(str))), str...), nil
+	case reflect.Bool:
+		if rv.Bool() {
+			return append(b, 0xf5), nil
+		}
+		return append(b, 0xf4), nil
+	}
+	return nil, fmt.Errorf("set: no CBOR encoding for %T", elem)
+}
+
+func (cborCodec[T]) DecodeElement(b []byte) (T, int, error) {
+	return decodeScalar[T](b, decodeCBORScalar)
+}
+
+func decodeCBORScalar(b []byte) (scalar, int, error) {
+	major, info, n, size, err := readCBORHead(b)
+	if err != nil {
+		return scalar{}, 0, err
+	}
+	if info == 31 {
+		return scalar{}, 0, fmt.Errorf("%w: indefinite length CBOR item not supported", ErrInvalidBinary)
+	}
+	switch major {
+	case cborUint:
+		return scalar{kind: reflect.Uint, u: n}, size, nil
+	case cborNegInt:
+		if n > math.MaxInt64 {
+			return scalar{}, 0, fmt.Errorf("%w: CBOR integer overflow", ErrInvalidBinary)
+		}
+		return scalar{kind: reflect.Int, i: -1 - int64(n)}, size, nil
+	case cborText:
+		return readString(b, size, n)
+	case cborSimple:
+		switch info {
+		case 20, 21:
+			return scalar{kind: reflect.Bool, b: info == 21}, size, nil
+		case 25:
+			return scalar{kind: reflect.Float64, f: halfToFloat(uint16(n))}, size, nil
+		case 26:
+			return scalar{kind: reflect.Float64, f: float64(math.Float32frombits(uint32(n)))}, size, nil
+		case 27:
+			return scalar{kind: reflect.Float64, f: math.Float64frombits(n)}, size, nil
+		}
+	}
+	return scalar{}, 0, fmt.Errorf("%w: unsupported CBOR item 0x%02x", ErrInvalidBinary, b[0])
+}
+
+// halfToFloat converts an IEEE 754 half-precision number to float64.
+func halfToFloat(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	}
+	return sign * math.Ldexp(mant+0x400, exp-25)
+}
+
+// AppendCBOR appends the CBOR encoding of s as array with tag 258 (a mathematical
+// finite set) to b and returns the extended buffer. If c is nil, elements whose
+// underlying type is an integer, floating-point, string or bool type are supported.
+// Otherwise c is used for all elements and must encode each one as a CBOR data item.
+// The elements are sorted if the underlying type of T is ordered.
+func AppendCBOR[T any](b []byte, s ReadOnlySet[T], c Codec[T]) ([]byte, error) {
+	if c == nil {
+		c = cborCodec[T]{}
+	}
+	b = appendCBORHead(b, cborTag, cborTagSet)
+	b = appendCBORHead(b, cborArray, uint64(s.Cardinality()))
+	var err error
+	for _, elem := range sortIfOrdered(s.Elements()) {
+		if b, err = c.AppendElement(b, elem); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// DecodeCBOR decodes a CBOR array with or without tag 258 and adds the elements to s.
+// Arrays of indefinite length are supported. Codec c must be the same that was
+// used for encoding (see [AppendCBOR]).
+func DecodeCBOR[T any](data []byte, s Set[T], c Codec[T]) error {
+	if c == nil {
+		c = cborCodec[T]{}
+	}
+	major, info, n, pos, err := readCBORHead(data)
+	if err != nil {
+		return err
+	}
+	if major == cborTag {
+		if n != cborTagSet {
+			return fmt.Errorf("%w: unexpected CBOR tag %d", ErrInvalidBinary, n)
+		}
+		var size int
+		if major, info, n, size, err = readCBORHead(data[pos:]); err != nil {
+			return err
+		}
+		pos += size
+	}
+	if major != cborArray {
+		return fmt.Errorf("%w: not a CBOR array", ErrInvalidBinary)
+	}
+	indefinite := info == 31
+	if !indefinite && n > uint64(len(data)-pos) {
+		return errTruncated
+	}
+	for i := uint64(0); indefinite || i < n; i++ {
+		if indefinite {
+			if pos >= len(data) {
+				return errTruncated
+			}
+			if data[pos] == 0xff {
+				pos++
+				break
+			}
+		}
+		elem, size, err := c.DecodeElement(data[pos:])
+		if err != nil {
+			return fmt.Errorf("element at offset %d: %w", pos, err)
+		}
+		pos += size
+		s.Add(elem)
+	}
+	if pos != len(data) {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidBinary, len(data)-pos)
+	}
+	return nil
+}
+
+// MarshalCBOR returns the CBOR encoding of the Set (see [AppendCBOR]).
+func (s *MapSet[T]) MarshalCBOR() ([]byte, error) {
+	return AppendCBOR[T](nil, s, nil)
+}
+
+// UnmarshalCBOR decodes a CBOR array (see [DecodeCBOR]).
+// The elements of the Set are replaced by the decoded elements.
+func (s *MapSet[T]) UnmarshalCBOR(data []byte) error {
+	s.Clear()
+	return DecodeCBOR[T](data, s, nil)
+}
+
+// MarshalCBOR returns the CBOR encoding of the Set (see [AppendCBOR]).
+func (s *TreeSet[T]) MarshalCBOR() ([]byte, error) {
+	return AppendCBOR[T](nil, s, nil)
+}
+
+// UnmarshalCBOR decodes a CBOR array (see [DecodeCBOR]).
+// The elements of the Set are replaced by the decoded elements.
+func (s *TreeSet[T]) UnmarshalCBOR(data []byte) error {
+	s.Clear()
+	return DecodeCBOR[T](data, s, nil)
+}
@@ -0,0 +1,106 @@
+package set
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCBOREncoding(t *testing.T) {
+	var tests = []struct {
+		s    interface{ MarshalCBOR() ([]byte, error) }
+		want []byte
+	}{
+		{NewMapSet[int](), []byte{0xd9, 0x01, 0x02, 0x80}},
+		{NewMapSet(3, 1, 2), []byte{0xd9, 0x01, 0x02, 0x83, 0x01, 0x02, 0x03}},
+		{NewTreeSet(-1, -1000, 1000000), []byte{0xd9, 0x01, 0x02, 0x83, 0x39, 0x03, 0xe7, 0x20, 0x1a, 0x00, 0x0f, 0x42, 0x40}},
+		{NewTreeSet[uint64](math.MaxUint64), []byte{0xd9, 0x01, 0x02, 0x81, 0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{NewMapSet("a", "IETF"), []byte{0xd9, 0x01, 0x02, 0x82, 0x64, 'I', 'E', 'T', 'F', 0x61, 'a'}},
+		{NewMapSet(false), []byte{0xd9, 0x01, 0x02, 0x81, 0xf4}},
+		{NewMapSet(1.1), []byte{0xd9, 0x01, 0x02, 0x81, 0xfb, 0x3f, 0xf1, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a}},
+	}
+	for i, test := range tests {
+		got, err := test.s.MarshalCBOR()
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("%d: got % x, want % x", i, got, test.want)
+		}
+	}
+}
+
+func TestCBORDecoding(t *testing.T) {
+	var tests = []struct {
+		data []byte
+		want []float64
+	}{
+		{[]byte{0x80}, []float64{}},
+		{[]byte{0x82, 0xf9, 0x3e, 0x00, 0xf9, 0x80, 0x00}, []float64{-0.0, 1.5}},
+		{[]byte{0x9f, 0xf9, 0x7c, 0x00, 0xfa, 0x47, 0xc3, 0x50, 0x00, 0xff}, []float64{100000, math.Inf(1)}},
+		{[]byte{0xd9, 0x01, 0x02, 0x81, 0xf9, 0x00, 0x01}, []float64{5.960464477539063e-8}},
+	}
+	for i, test := range tests {
+		s := NewTreeSet(42.0)
+		if err := s.UnmarshalCBOR(test.data); err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if !s.Equal(NewTreeSet(test.want...)) {
+			t.Errorf("%d: got %v, want %v", i, s, test.want)
+		}
+	}
+	if f := halfToFloat(0x7e00); !math.IsNaN(f) {
+		t.Errorf("got %v, want NaN", f)
+	}
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	s := NewMapSet[int64](math.MinInt64, math.MaxInt64, 0, -24, -25, 23, 24, 255, 256, 65536)
+	data, err := s.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2 := NewTreeSet[int64]()
+	if err := s2.UnmarshalCBOR(data); err != nil {
+		t.Fatal(err)
+	}
+	if !s2.Equal(s) {
+		t.Errorf("got %v, want %v", s2, s)
+	}
+	c := pointCodec{0x82, cborCodec[int]{}}
+	ps := NewMapSet(point{1, 2}, point{-3, 4})
+	if data, err = AppendCBOR[point](nil, ps, c); err != nil {
+		t.Fatal(err)
+	}
+	ps2 := NewMapSet[point]()
+	if err := DecodeCBOR[point](data, ps2, c); err != nil {
+		t.Fatal(err)
+	}
+	if !ps2.Equal(ps) {
+		t.Errorf("got %v, want %v", ps2, ps)
+	}
+}
+
+func TestCBORErrors(t *testing.T) {
+	var tests = [][]byte{
+		{},
+		{0xa0},
+		{0xd9, 0x01, 0x03, 0x80},
+		{0x82, 0x01},
+		{0x81, 0x01, 0x02},
+		{0x81, 0x61},
+		{0x81, 0x19, 0x01, 0x00},
+		{0x81, 0x20},
+		{0x81, 0x3b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		{0x81, 0x7f},
+		{0x9f, 0x01},
+		{0x81, 0x1c},
+		{0x81, 0xf6},
+	}
+	for i, data := range tests {
+		if err := DecodeCBOR(data, NewMapSet[uint8](), nil); !errors.Is(err, ErrInvalidBinary) {
+			t.Errorf("%d: got %v, want %v", i, err, ErrInvalidBinary)
+		}
+	}
+}
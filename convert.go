@@ -0,0 +1,73 @@
+package set
+
+import (
+	"cmp"
+	"context"
+	"iter"
+
+	"github.com/andreas19/avltree"
+)
+
+// Collect returns a new MapSet with the elements of seq.
+func Collect[T comparable](seq iter.Seq[T]) *MapSet[T] {
+	s := NewMapSet[T]()
+	s.UpdateSeq(seq)
+	return s
+}
+
+// CollectTree returns a new TreeSet with the elements of seq.
+// Function cmp is used to compare two elements (see [NewTreeSetFunc]).
+func CollectTree[T any](cmp func(T, T) int, seq iter.Seq[T]) *TreeSet[T] {
+	s := NewTreeSetFunc(cmp)
+	s.UpdateSeq(seq)
+	return s
+}
+
+// FromMapKeys returns a new MapSet with the keys of m.
+func FromMapKeys[K comparable, V any](m map[K]V) *MapSet[K] {
+	data := make(map[K]struct{}, len(m))
+	for k := range m {
+		data[k] = struct{}{}
+	}
+	return &MapSet[K]{data: data}
+}
+
+// FromChan returns a new MapSet with the elements received from ch until ch
+// is closed or ctx is done. If ctx is done, the elements received so far
+// and ctx.Err() are returned.
+func FromChan[T comparable](ctx context.Context, ch <-chan T) (*MapSet[T], error) {
+	s := NewMapSet[T]()
+	for {
+		select {
+		case <-ctx.Done():
+			return s, ctx.Err()
+		case elem, ok := <-ch:
+			if !ok {
+				return s, nil
+			}
+			s.data[elem] = struct{}{}
+		}
+	}
+}
+
+// ToMapSet returns a new MapSet with the elements of s.
+func ToMapSet[T comparable](s ReadOnlySet[T]) *MapSet[T] {
+	data := make(map[T]struct{}, s.Cardinality())
+	for elem := range s.Iter() {
+		data[elem] = struct{}{}
+	}
+	return &MapSet[T]{data: data}
+}
+
+// ToTreeSet returns a new TreeSet with the elements of s.
+func ToTreeSet[T cmp.Ordered](s ReadOnlySet[T]) *TreeSet[T] {
+	result := ToTreeSetFunc(avltree.CmpOrd[T], s)
+	result.ordered = true
+	return result
+}
+
+// ToTreeSetFunc returns a new TreeSet with the elements of s.
+// Function cmp is used to compare two elements (see [NewTreeSetFunc]).
+func ToTreeSetFunc[T any](cmp func(T, T) int, s ReadOnlySet[T]) *TreeSet[T] {
+	return CollectTree(cmp, s.Iter())
+}
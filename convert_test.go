@@ -0,0 +1,75 @@
+package set
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	s := Collect(slices.Values([]int{1, 2, 1}))
+	if want := map[int]struct{}{1: {}, 2: {}}; !reflect.DeepEqual(s.data, want) {
+		t.Errorf("got %v, want %v", s.data, want)
+	}
+}
+
+func TestCollectTree(t *testing.T) {
+	s := CollectTree(func(a, b int) int { return b - a }, slices.Values([]int{1, 3, 2, 1}))
+	if got, want := s.Elements(), []int{3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFromMapKeys(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	s := FromMapKeys(m)
+	if !s.Equal(Collect(maps.Keys(m))) {
+		t.Errorf("got %v, want {a, b}", s)
+	}
+}
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 1
+	close(ch)
+	s, err := FromChan(context.Background(), ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int]struct{}{1: {}, 2: {}}; !reflect.DeepEqual(s.data, want) {
+		t.Errorf("got %v, want %v", s.data, want)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := FromChan(ctx, make(chan int)); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestToMapSet(t *testing.T) {
+	s := ToMapSet[int](NewTreeSet(1, 2))
+	if want := map[int]struct{}{1: {}, 2: {}}; !reflect.DeepEqual(s.data, want) {
+		t.Errorf("got %v, want %v", s.data, want)
+	}
+}
+
+func TestToTreeSet(t *testing.T) {
+	s := ToTreeSet[int](NewMapSet(3, 1, 2))
+	if got, want := s.Elements(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestUpdateSeq(t *testing.T) {
+	for i, s := range []Set[int]{NewMapSet(1), NewTreeSet(1)} {
+		s.UpdateSeq(slices.Values([]int{2, 3, 2}))
+		if !s.Equal(NewMapSet(1, 2, 3)) {
+			t.Errorf("%d: got %v, want {1, 2, 3}", i, s)
+		}
+	}
+}
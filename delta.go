@@ -0,0 +1,119 @@
+package set
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Delta describes the changes between two sets.
+// Added contains the elements that were added, Removed the elements that were removed.
+// A nil Set is treated as empty, so the zero value is an empty Delta.
+type Delta[T any] struct {
+	Added   Set[T]
+	Removed Set[T]
+}
+
+// Diff returns the Delta that turns old into new.
+// Added has the implementation of new, Removed the implementation of old.
+func Diff[T any](old, new ReadOnlySet[T]) Delta[T] {
+	return Delta[T]{
+		Added:   new.Difference(old),
+		Removed: old.Difference(new),
+	}
+}
+
+// elementsOf returns the elements of s sorted if the underlying type of T is ordered.
+// s may be nil.
+func elementsOf[T any](s Set[T]) []T {
+	if s == nil {
+		return nil
+	}
+	return sortIfOrdered(s.Elements())
+}
+
+// contains reports whether elem is in s. s may be nil.
+func contains[T any](s Set[T], elem T) bool {
+	return s != nil && s.Contains(elem)
+}
+
+// emptyLike returns an empty Set with the implementation of the first
+// Set in sets that is not nil, or nil if all are nil.
+func emptyLike[T any](sets ...Set[T]) Set[T] {
+	for _, s := range sets {
+		if s != nil {
+			e := s.Clone()
+			e.Clear()
+			return e
+		}
+	}
+	return nil
+}
+
+// Apply applies the Delta to s: all removed elements are removed from s,
+// then all added elements are added to s.
+func (d Delta[T]) Apply(s Set[T]) {
+	if d.Removed != nil {
+		for elem := range d.Removed.Iter() {
+			s.Remove(elem)
+		}
+	}
+	if d.Added != nil {
+		for elem := range d.Added.Iter() {
+			s.Add(elem)
+		}
+	}
+}
+
+// Invert returns the Delta that reverts d.
+func (d Delta[T]) Invert() Delta[T] {
+	return Delta[T]{Added: d.Removed, Removed: d.Added}
+}
+
+// Compose returns a Delta that has the same effect as applying d and then d2.
+// Elements that are added by one Delta and removed by the other cancel out.
+func (d Delta[T]) Compose(d2 Delta[T]) Delta[T] {
+	added := emptyLike(d.Added, d2.Added, d.Removed, d2.Removed)
+	removed := emptyLike(d.Removed, d2.Removed, d.Added, d2.Added)
+	// (d.Added \ d2.Removed) ∪ (d2.Added \ d.Removed)
+	for _, elem := range elementsOf(d.Added) {
+		if !contains(d2.Removed, elem) {
+			added.Add(elem)
+		}
+	}
+	for _, elem := range elementsOf(d2.Added) {
+		if !contains(d.Removed, elem) {
+			added.Add(elem)
+		}
+	}
+	// (d.Removed \ d2.Added) ∪ (d2.Removed \ d.Added)
+	for _, elem := range elementsOf(d.Removed) {
+		if !contains(d2.Added, elem) {
+			removed.Add(elem)
+		}
+	}
+	for _, elem := range elementsOf(d2.Removed) {
+		if !contains(d.Added, elem) {
+			removed.Add(elem)
+		}
+	}
+	return Delta[T]{Added: added, Removed: removed}
+}
+
+// IsEmpty returns true if the Delta contains no changes.
+func (d Delta[T]) IsEmpty() bool {
+	return (d.Added == nil || d.Added.IsEmpty()) && (d.Removed == nil || d.Removed.IsEmpty())
+}
+
+// String returns a unified representation of the Delta with one line per element.
+// Removed elements are prefixed with "- ", added elements with "+ ".
+// The elements are sorted if the underlying type of T is ordered.
+func (d Delta[T]) String() string {
+	var sb strings.Builder
+	for _, elem := range elementsOf(d.Removed) {
+		fmt.Fprintf(&sb, "- %v\n", elem)
+	}
+	for _, elem := range elementsOf(d.Added) {
+		fmt.Fprintf(&sb, "+ %v\n", elem)
+	}
+	return sb.String()
+}
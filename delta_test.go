@@ -0,0 +1,108 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	var tests = []struct {
+		old, new       *TreeSet[int]
+		added, removed []int
+	}{
+		{NewTreeSet[int](), NewTreeSet[int](), []int{}, []int{}},
+		{NewTreeSet[int](), NewTreeSet(1, 2), []int{1, 2}, []int{}},
+		{NewTreeSet(1, 2), NewTreeSet[int](), []int{}, []int{1, 2}},
+		{NewTreeSet(1, 2), NewTreeSet(2, 3), []int{3}, []int{1}},
+		{NewTreeSet(1, 2), NewTreeSet(1, 2), []int{}, []int{}},
+	}
+	for i, test := range tests {
+		d := Diff[int](test.old, test.new)
+		if got := d.Added.Elements(); !reflect.DeepEqual(got, test.added) {
+			t.Errorf("%d: got added %v, want %v", i, got, test.added)
+		}
+		if got := d.Removed.Elements(); !reflect.DeepEqual(got, test.removed) {
+			t.Errorf("%d: got removed %v, want %v", i, got, test.removed)
+		}
+		if got, want := d.IsEmpty(), len(test.added)+len(test.removed) == 0; got != want {
+			t.Errorf("%d: got IsEmpty %t, want %t", i, got, want)
+		}
+	}
+}
+
+func TestDeltaApply(t *testing.T) {
+	old := NewTreeSet(1, 2, 3)
+	new := NewTreeSet(2, 3, 4)
+	d := Diff[int](old, new)
+	s := NewMapSet(1, 2, 3)
+	d.Apply(s)
+	if !s.Equal(new) {
+		t.Errorf("got %v, want %v", s, new)
+	}
+	d.Invert().Apply(s)
+	if !s.Equal(old) {
+		t.Errorf("got %v, want %v", s, old)
+	}
+}
+
+func TestDeltaCompose(t *testing.T) {
+	s1 := NewTreeSet(1, 2, 3)
+	s2 := NewTreeSet(2, 3, 4)
+	s3 := NewTreeSet(1, 3, 5)
+	d := Diff[int](s1, s2).Compose(Diff[int](s2, s3))
+	if got, want := d.Added.Elements(), []int{5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got added %v, want %v", got, want)
+	}
+	if got, want := d.Removed.Elements(), []int{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got removed %v, want %v", got, want)
+	}
+	s := s1.Clone()
+	d.Apply(s)
+	if !s.Equal(s3) {
+		t.Errorf("got %v, want %v", s, s3)
+	}
+}
+
+func TestDeltaString(t *testing.T) {
+	d := Diff[int](NewTreeSet(1, 2), NewTreeSet(2, 3, 4))
+	want := "- 1\n+ 3\n+ 4\n"
+	if got := d.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDeltaZero(t *testing.T) {
+	var d Delta[int]
+	if !d.IsEmpty() {
+		t.Error("zero Delta is not empty")
+	}
+	if got := d.String(); got != "" {
+		t.Errorf("got %q, want empty string", got)
+	}
+	s := NewMapSet(1)
+	d.Apply(s)
+	if !s.Equal(NewMapSet(1)) {
+		t.Errorf("got %v, want {1}", s)
+	}
+	if c := d.Compose(Delta[int]{}); !c.IsEmpty() || c.Added != nil || c.Removed != nil {
+		t.Errorf("got %v, want zero Delta", c)
+	}
+	c := d.Compose(Delta[int]{Added: NewMapSet(2)})
+	if got, want := c.String(), "+ 2\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	c.Apply(s)
+	if !s.Equal(NewMapSet(1, 2)) {
+		t.Errorf("got %v, want {1, 2}", s)
+	}
+}
+
+func TestDeltaStringSorted(t *testing.T) {
+	d := Diff[int](NewMapSet(9, 7, 8, 1), NewMapSet(1, 6, 4, 5, 2, 3))
+	want := "- 7\n- 8\n- 9\n+ 2\n+ 3\n+ 4\n+ 5\n+ 6\n"
+	for range 10 {
+		if got := d.String(); got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,671 @@
+package set
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// ErrClosed is returned if a [DurableSet] is used after it was closed.
+var ErrClosed = errors.New("set: durable set is closed")
+
+// File names in the directory of a DurableSet.
+const (
+	snapshotFile = "snapshot"
+	walFile      = "wal"
+)
+
+// Operations of log records.
+const (
+	walAdd = iota + 1
+	walRemove
+	walClear
+)
+
+// SyncPolicy controls when the log of a [DurableSet] is synced to stable storage.
+type SyncPolicy int
+
+const (
+	SyncAlways   SyncPolicy = iota // after every record
+	SyncInterval                   // after a record if DurableOptions.SyncInterval has passed since the last sync
+	SyncNever                      // only by Sync, Compact and Close
+)
+
+// DurableOptions configures a [DurableSet]. A nil *DurableOptions is the same
+// as the zero value.
+type DurableOptions struct {
+	Sync         SyncPolicy    // when the log is synced
+	SyncInterval time.Duration // minimum time between syncs with SyncInterval
+
+	// The log is compacted automatically when it has at least CompactAfter records
+	// and more records than the Set has elements. If it is 0, only Compact does it.
+	CompactAfter int
+}
+
+// DurableSet is a [Set] that persists every effective change to an append-only
+// log file in a directory. The log is compacted into a snapshot file (see
+// [DurableSet.Compact]). Opening a DurableSet recovers its elements from both files.
+//
+// Each log record consists of a header with an operation byte and the length
+// of the encoded element as uvarint, a CRC-32 checksum of the header, the encoded
+// element and a CRC-32 checksum of all of them. A torn final record, e.g. after
+// a crash, is discarded, as are zero bytes at the end of the file; any other
+// damage makes opening fail.
+//
+// Methods that modify the Set cannot return errors. The first error that
+// occurs while writing is kept and returned by [DurableSet.Err]; after that,
+// changes are made in memory only. A DurableSet is not safe for concurrent use.
+type DurableSet[T any] struct {
+	set     Set[T]
+	codec   Codec[T] // for snapshots, may be nil
+	elem    Codec[T] // for log records
+	dir     string
+	opts    DurableOptions
+	wal     *os.File
+	records int       // number of records in the log
+	synced  time.Time // time of the last sync
+	err     error
+}
+
+// OpenDurableSet opens the DurableSet in directory dir and creates the directory
+// if it does not exist. The elements are kept in memory in s, which is cleared first.
+// If c is nil, elements are encoded like with [AppendBinary], otherwise with c.
+// The same Codec must be used every time the DurableSet is opened.
+func OpenDurableSet[T any](dir string, s Set[T], c Codec[T], opts *DurableOptions) (*DurableSet[T], error) {
+	if opts == nil {
+		opts = &DurableOptions{}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	s.Clear()
+	d := &DurableSet[T]{set: s, codec: c, elem: elementCodec(c), dir: dir, opts: *opts, synced: time.Now()}
+	if err := d.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, walFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.recover(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	d.wal = f
+	return d, nil
+}
+
+// loadSnapshot adds the elements of the snapshot file to the Set.
+func (d *DurableSet[T]) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(d.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	n := len(data) - 4
+	if n < 0 || crc32.ChecksumIEEE(data[:n]) != binary.LittleEndian.Uint32(data[n:]) {
+		return fmt.Errorf("%w: snapshot checksum mismatch", ErrInvalidBinary)
+	}
+	return DecodeBinary(data[:n], d.set, d.codec)
+}
+
+// recover replays the records of the log file f and truncates a torn final record.
+func (d *DurableSet[T]) recover(f *os.File) error {
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	pos := 0
+	for pos < len(data) {
+		rec := data[pos:]
+		l, n := binary.Uvarint(rec[1:])
+		if n < 0 {
+			return fmt.Errorf("%w: invalid record length at offset %d", ErrInvalidBinary, pos)
+		}
+		hdr := 1 + n
+		if n == 0 || hdr+4 > len(rec) {
+			break // torn header
+		}
+		// the length is only trusted if the header is intact, so that a damaged
+		// length cannot make the following records look like a torn tail;
+		// zero bytes are what a crash while the file is extended leaves
+		if crc32.ChecksumIEEE(rec[:hdr]) != binary.LittleEndian.Uint32(rec[hdr:]) {
+			if allZero(rec) {
+				break // torn
+			}
+			return fmt.Errorf("%w: record header checksum mismatch at offset %d", ErrInvalidBinary, pos)
+		}
+		if l > uint64(len(rec)) || hdr+4+int(l)+4 > len(rec) {
+			break // torn element
+		}
+		end := hdr + 4 + int(l)
+		if crc32.ChecksumIEEE(rec[:end]) != binary.LittleEndian.Uint32(rec[end:]) {
+			if allZero(rec[end+4:]) {
+				break // torn
+			}
+			return fmt.Errorf("%w: record checksum mismatch at offset %d", ErrInvalidBinary, pos)
+		}
+		if err := d.apply(rec[0], rec[hdr+4:end]); err != nil {
+			return fmt.Errorf("record at offset %d: %w", pos, err)
+		}
+		pos += end + 4
+		d.records++
+	}
+	if pos < len(data) {
+		if err := f.Truncate(int64(pos)); err != nil {
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+	}
+	_, err = f.Seek(int64(pos), io.SeekStart)
+	return err
+}
+
+// allZero reports whether b contains only zero bytes.
+func allZero(b []byte) bool {
+	return !slices.ContainsFunc(b, func(c byte) bool { return c != 0 })
+}
+
+// apply applies a log record to the Set.
+func (d *DurableSet[T]) apply(op byte, payload []byte) error {
+	if op == walClear {
+		d.set.Clear()
+		return nil
+	}
+	elem, n, err := d.elem.DecodeElement(payload)
+	if err != nil {
+		return err
+	}
+	if n != len(payload) {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidBinary, len(payload)-n)
+	}
+	switch op {
+	case walAdd:
+		d.set.Add(elem)
+	case walRemove:
+		d.set.Remove(elem)
+	default:
+		return fmt.Errorf("%w: unknown operation %d", ErrInvalidBinary, op)
+	}
+	return nil
+}
+
+// log appends a record to the log file.
+func (d *DurableSet[T]) log(op byte, elem T) {
+	if d.err != nil {
+		return
+	}
+	if d.wal == nil {
+		d.err = ErrClosed
+		return
+	}
+	var payload []byte
+	if op != walClear {
+		if payload, d.err = d.elem.AppendElement(nil, elem); d.err != nil {
+			return
+		}
+	}
+	b := binary.AppendUvarint([]byte{op}, uint64(len(payload)))
+	b = binary.LittleEndian.AppendUint32(b, crc32.ChecksumIEEE(b))
+	b = append(b, payload...)
+	b = binary.LittleEndian.AppendUint32(b, crc32.ChecksumIEEE(b))
+	if _, d.err = d.wal.Write(b); d.err != nil {
+		return
+	}
+	d.records++
+	switch {
+	case d.opts.CompactAfter > 0 && d.records >= d.opts.CompactAfter && d.records > d.set.Cardinality():
+		d.Compact()
+	case d.opts.Sync == SyncAlways,
+		d.opts.Sync == SyncInterval && time.Since(d.synced) >= d.opts.SyncInterval:
+		d.Sync()
+	}
+}
+
+// Err returns the first error that occurred while writing to the files.
+func (d *DurableSet[T]) Err() error {
+	return d.err
+}
+
+// Sync syncs the log file to stable storage.
+func (d *DurableSet[T]) Sync() error {
+	if d.err != nil {
+		return d.err
+	}
+	if d.wal == nil {
+		return ErrClosed
+	}
+	if d.err = d.wal.Sync(); d.err == nil {
+		d.synced = time.Now()
+	}
+	return d.err
+}
+
+// Compact writes all elements to a new snapshot file and empties the log file.
+// The snapshot is written to a temporary file that is synced and then renamed,
+// so that a crash leaves either the old or the new snapshot.
+func (d *DurableSet[T]) Compact() error {
+	if d.err != nil {
+		return d.err
+	}
+	if d.wal == nil {
+		return ErrClosed
+	}
+	d.err = d.compact()
+	return d.err
+}
+
+func (d *DurableSet[T]) compact() error {
+	data, err := AppendBinary(nil, d.set, d.codec)
+	if err != nil {
+		return err
+	}
+	data = binary.LittleEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+	tmp := filepath.Join(d.dir, snapshotFile+".tmp")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err == nil {
+		err = os.Rename(tmp, filepath.Join(d.dir, snapshotFile))
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := syncDir(d.dir); err != nil {
+		return err
+	}
+	// replaying the old records on top of the new snapshot yields the same elements,
+	// so a crash before the log is emptied does no harm
+	if err := d.wal.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := d.wal.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := d.wal.Sync(); err != nil {
+		return err
+	}
+	d.records = 0
+	d.synced = time.Now()
+	return nil
+}
+
+// syncDir syncs a directory so that a rename in it is durable.
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	return err
+}
+
+// Close syncs and closes the log file. The elements stay available in memory,
+// but changes are no longer persisted and make [DurableSet.Err] return [ErrClosed].
+func (d *DurableSet[T]) Close() error {
+	if d.wal == nil {
+		return ErrClosed
+	}
+	err := d.wal.Sync()
+	if err1 := d.wal.Close(); err == nil {
+		err = err1
+	}
+	d.wal = nil
+	if d.err != nil {
+		return d.err
+	}
+	return err
+}
+
+// elementCodec returns c, or the Codec that is used for single elements in the
+// log if c is nil: integers as varints, strings with their length, and all other
+// types with [encoding/gob].
+func elementCodec[T any](c Codec[T]) Codec[T] {
+	if c != nil {
+		return c
+	}
+	switch binaryLayout[T]() {
+	case layoutInt:
+		return intCodec[T]{}
+	case layoutUint:
+		return uintCodec[T]{}
+	case layoutCodec:
+		return stringCodec[T]{}
+	}
+	return gobCodec[T]{}
+}
+
+// intCodec encodes elements whose underlying type is a signed integer type as varint.
+type intCodec[T any] struct{}
+
+func (intCodec[T]) AppendElement(b []byte, elem T) ([]byte, error) {
+	return binary.AppendVarint(b, reflect.ValueOf(elem).Int()), nil
+}
+
+func (intCodec[T]) DecodeElement(b []byte) (T, int, error) {
+	var elem T
+	v, n := binary.Varint(b)
+	rv := reflect.ValueOf(&elem).Elem()
+	if n <= 0 || rv.OverflowInt(v) {
+		return elem, 0, ErrInvalidBinary
+	}
+	rv.SetInt(v)
+	return elem, n, nil
+}
+
+// uintCodec encodes elements whose underlying type is an unsigned integer type as uvarint.
+type uintCodec[T any] struct{}
+
+func (uintCodec[T]) AppendElement(b []byte, elem T) ([]byte, error) {
+	return binary.AppendUvarint(b, reflect.ValueOf(elem).Uint()), nil
+}
+
+func (uintCodec[T]) DecodeElement(b []byte) (T, int, error) {
+	var elem T
+	v, n := binary.Uvarint(b)
+	rv := reflect.ValueOf(&elem).Elem()
+	if n <= 0 || rv.OverflowUint(v) {
+		return elem, 0, ErrInvalidBinary
+	}
+	rv.SetUint(v)
+	return elem, n, nil
+}
+
+// gobCodec encodes elements with encoding/gob, prefixed by the length as uvarint.
+type gobCodec[T any] struct{}
+
+func (gobCodec[T]) AppendElement(b []byte, elem T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(elem); err != nil {
+		return nil, err
+	}
+	b = binary.AppendUvarint(b, uint64(buf.Len()))
+	return append(b, buf.Bytes()...), nil
+}
+
+func (gobCodec[T]) DecodeElement(b []byte) (T, int, error) {
+	var elem T
+	l, n := binary.Uvarint(b)
+	if n <= 0 || l > uint64(len(b)-n) {
+		return elem, 0, ErrInvalidBinary
+	}
+	if err := gob.NewDecoder(bytes.NewReader(b[n : n+int(l)])).Decode(&elem); err != nil {
+		return elem, 0, err
+	}
+	return elem, n + int(l), nil
+}
+
+// Contains reports whether the element is in the Set.
+func (d *DurableSet[T]) Contains(elem T) bool {
+	return d.set.Contains(elem)
+}
+
+// Add adds an element to the Set.
+// Returns true if it was added, false if it already was in the set.
+func (d *DurableSet[T]) Add(elem T) bool {
+	if !d.set.Add(elem) {
+		return false
+	}
+	d.log(walAdd, elem)
+	return true
+}
+
+// Update updates the Set with elems.
+func (d *DurableSet[T]) Update(elems ...T) {
+	for _, elem := range elems {
+		d.Add(elem)
+	}
+}
+
+// UpdateSeq updates the Set with the elements of seq.
+func (d *DurableSet[T]) UpdateSeq(seq iter.Seq[T]) {
+	for elem := range seq {
+		d.Add(elem)
+	}
+}
+
+// Remove removes an element from the Set.
+// Returns true if it was in the set, false otherwise.
+func (d *DurableSet[T]) Remove(elem T) bool {
+	if !d.set.Remove(elem) {
+		return false
+	}
+	d.log(walRemove, elem)
+	return true
+}
+
+// Clear removes all elements from the Set.
+func (d *DurableSet[T]) Clear() {
+	if d.set.IsEmpty() {
+		return
+	}
+	d.set.Clear()
+	var zero T
+	d.log(walClear, zero)
+}
+
+// Pop removes and returns an arbitrary element of the Set.
+// Returns false if the Set is empty.
+func (d *DurableSet[T]) Pop() (T, bool) {
+	elem, ok := d.set.Pop()
+	if ok {
+		d.log(walRemove, elem)
+	}
+	return elem, ok
+}
+
+// RemoveIf removes all elements from the Set for which pred returns true.
+// Returns the number of elements that were removed.
+func (d *DurableSet[T]) RemoveIf(pred func(T) bool) int {
+	var removed []T
+	d.set.RemoveIf(func(elem T) bool {
+		if pred(elem) {
+			removed = append(removed, elem)
+			return true
+		}
+		return false
+	})
+	for _, elem := range removed {
+		d.log(walRemove, elem)
+	}
+	return len(removed)
+}
+
+// RetainIf removes all elements from the Set for which pred returns false.
+// Returns the number of elements that were removed.
+func (d *DurableSet[T]) RetainIf(pred func(T) bool) int {
+	return d.RemoveIf(func(elem T) bool {
+		return !pred(elem)
+	})
+}
+
+// UnionWith adds all elements of s2 to s.
+// Returns the number of elements that were added.
+func (d *DurableSet[T]) UnionWith(s2 ReadOnlySet[T]) int {
+	if same[T](d, s2) {
+		return 0
+	}
+	n := 0
+	for elem := range s2.Iter() {
+		if d.Add(elem) {
+			n++
+		}
+	}
+	return n
+}
+
+// IntersectWith removes all elements from s that are not in s2.
+// Returns the number of elements that were removed.
+func (d *DurableSet[T]) IntersectWith(s2 ReadOnlySet[T]) int {
+	if same[T](d, s2) {
+		return 0
+	}
+	return d.RemoveIf(func(elem T) bool {
+		return !s2.Contains(elem)
+	})
+}
+
+// DifferenceWith removes all elements of s2 from s.
+// Returns the number of elements that were removed.
+func (d *DurableSet[T]) DifferenceWith(s2 ReadOnlySet[T]) int {
+	if same[T](d, s2) {
+		n := d.set.Cardinality()
+		d.Clear()
+		return n
+	}
+	return d.RemoveIf(s2.Contains)
+}
+
+// SymDifferenceWith removes all elements of s2 from s that are in s
+// and adds all others. Returns the number of elements that were added or removed.
+func (d *DurableSet[T]) SymDifferenceWith(s2 ReadOnlySet[T]) int {
+	if same[T](d, s2) {
+		n := d.set.Cardinality()
+		d.Clear()
+		return n
+	}
+	n := 0
+	for elem := range s2.Iter() {
+		if !d.Remove(elem) {
+			d.Add(elem)
+		}
+		n++
+	}
+	return n
+}
+
+// IsEmpty returns true if Set is an empty set.
+func (d *DurableSet[T]) IsEmpty() bool {
+	return d.set.IsEmpty()
+}
+
+// Cardinality returns the number of elements in the Set.
+func (d *DurableSet[T]) Cardinality() int {
+	return d.set.Cardinality()
+}
+
+// Any returns an arbitrary element of the Set without removing it.
+// Returns false if the Set is empty.
+func (d *DurableSet[T]) Any() (T, bool) {
+	return d.set.Any()
+}
+
+// Union returns a new Set which is the union of s and s2.
+// The result is not durable.
+func (d *DurableSet[T]) Union(s2 ReadOnlySet[T]) Set[T] {
+	return d.set.Union(s2)
+}
+
+// Intersection returns a new Set which is the intersection of s and s2.
+// The result is not durable.
+func (d *DurableSet[T]) Intersection(s2 ReadOnlySet[T]) Set[T] {
+	return d.set.Intersection(s2)
+}
+
+// Difference returns a new Set which is the set difference of s and s2.
+// The result is not durable.
+func (d *DurableSet[T]) Difference(s2 ReadOnlySet[T]) Set[T] {
+	return d.set.Difference(s2)
+}
+
+// SymDifference returns a new Set which is the symmetric difference of s and s2.
+// The result is not durable.
+func (d *DurableSet[T]) SymDifference(s2 ReadOnlySet[T]) Set[T] {
+	return d.set.SymDifference(s2)
+}
+
+// IntersectionCardinality returns the cardinality of the intersection of s and s2.
+func (d *DurableSet[T]) IntersectionCardinality(s2 ReadOnlySet[T]) int {
+	return d.set.IntersectionCardinality(s2)
+}
+
+// UnionCardinality returns the cardinality of the union of s and s2.
+func (d *DurableSet[T]) UnionCardinality(s2 ReadOnlySet[T]) int {
+	return d.set.UnionCardinality(s2)
+}
+
+// DifferenceCardinality returns the cardinality of the set difference of s and s2.
+func (d *DurableSet[T]) DifferenceCardinality(s2 ReadOnlySet[T]) int {
+	return d.set.DifferenceCardinality(s2)
+}
+
+// IsDisjoint returns true if s and s2 have no elements in common.
+func (d *DurableSet[T]) IsDisjoint(s2 ReadOnlySet[T]) bool {
+	return d.set.IsDisjoint(s2)
+}
+
+// Overlaps returns true if s and s2 have at least one element in common.
+func (d *DurableSet[T]) Overlaps(s2 ReadOnlySet[T]) bool {
+	return d.set.Overlaps(s2)
+}
+
+// IsSubset returns true if s is a subset of s2.
+func (d *DurableSet[T]) IsSubset(s2 ReadOnlySet[T]) bool {
+	return d.set.IsSubset(s2)
+}
+
+// IsProperSubset returns true if s is a proper subset of s2.
+func (d *DurableSet[T]) IsProperSubset(s2 ReadOnlySet[T]) bool {
+	return d.set.IsProperSubset(s2)
+}
+
+// IsSuperset returns true if s is a superset of s2.
+func (d *DurableSet[T]) IsSuperset(s2 ReadOnlySet[T]) bool {
+	return d.set.IsSuperset(s2)
+}
+
+// IsProperSuperset returns true if s is a proper superset of s2.
+func (d *DurableSet[T]) IsProperSuperset(s2 ReadOnlySet[T]) bool {
+	return d.set.IsProperSuperset(s2)
+}
+
+// Equal returns true if s and s2 contain the same elements.
+func (d *DurableSet[T]) Equal(s2 ReadOnlySet[T]) bool {
+	return d.set.Equal(s2)
+}
+
+// Clone returns a copy of the Set that is not durable.
+func (d *DurableSet[T]) Clone() Set[T] {
+	return d.set.Clone()
+}
+
+// Elements returns a slice with all elements of the Set.
+func (d *DurableSet[T]) Elements() []T {
+	return d.set.Elements()
+}
+
+// Iter returns an iterator over all elements of the Set.
+func (d *DurableSet[T]) Iter() iter.Seq[T] {
+	return d.set.Iter()
+}
+
+// String returns a string representation of the Set.
+func (d *DurableSet[T]) String() string {
+	return d.set.String()
+}
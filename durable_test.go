@@ -0,0 +1,283 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var _ Set[int] = (*DurableSet[int])(nil)
+
+func openDurable[T comparable](t *testing.T, dir string, c Codec[T], opts *DurableOptions) *DurableSet[T] {
+	t.Helper()
+	d, err := OpenDurableSet[T](dir, NewMapSet[T](), c, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fi.Size()
+}
+
+func TestDurableSet(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable[int](t, dir, nil, nil)
+	d.Update(1, 2, 3, 4, 5, 6)
+	d.Remove(2)
+	d.Remove(42)
+	d.RemoveIf(func(elem int) bool { return elem > 4 })
+	d.UnionWith(NewMapSet(7, 8))
+	d.SymDifferenceWith(NewMapSet(1, 9))
+	if d.Add(3) {
+		t.Error("got true, want false")
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := NewMapSet(3, 4, 7, 8, 9)
+	if !d.Equal(want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+	d = openDurable[int](t, dir, nil, nil)
+	defer d.Close()
+	if !d.Equal(want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+	if d.records != 13 {
+		t.Errorf("got %d records, want 13", d.records)
+	}
+	d.Clear()
+	d.Add(10)
+	d2 := openDurable[int](t, dir, nil, nil)
+	defer d2.Close()
+	if got, want := d2.Elements(), []int{10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDurableSetCompact(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable[string](t, dir, nil, &DurableOptions{Sync: SyncNever})
+	d.Update("a", "b", "c")
+	d.Remove("b")
+	if err := d.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if size := fileSize(t, filepath.Join(dir, walFile)); size != 0 {
+		t.Errorf("got log size %d, want 0", size)
+	}
+	d.Add("d")
+	d.Remove("a")
+	d.Close()
+	d = openDurable[string](t, dir, nil, nil)
+	defer d.Close()
+	if want := NewMapSet("c", "d"); !d.Equal(want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, snapshotFile+".tmp")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestDurableSetAutoCompact(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable[uint](t, dir, nil, &DurableOptions{Sync: SyncInterval, CompactAfter: 4})
+	d.Update(1, 2, 3, 4, 5)
+	if d.records != 5 {
+		t.Errorf("got %d records, want 5", d.records)
+	}
+	d.RemoveIf(func(elem uint) bool { return elem%2 == 0 })
+	// compacted after the first removal
+	if d.records != 1 {
+		t.Errorf("got %d records, want 1", d.records)
+	}
+	d.Close()
+	d = openDurable[uint](t, dir, nil, nil)
+	defer d.Close()
+	if want := NewMapSet[uint](1, 3, 5); !d.Equal(want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+}
+
+func TestDurableSetTornRecord(t *testing.T) {
+	const recLen = 12 // op, length, checksum, encoded element, checksum
+	var tests = []struct {
+		name   string
+		damage func(data []byte) []byte
+		good   int // length of the log after recovery
+		want   []string
+	}{
+		{"truncated", func(data []byte) []byte { return data[:len(data)-2] }, recLen, []string{"a"}},
+		{"truncated header", func(data []byte) []byte { return data[:len(data)-9] }, recLen, []string{"a"}},
+		{"truncated element", func(data []byte) []byte { return data[:len(data)-5] }, recLen, []string{"a"}},
+		{"partial header", func(data []byte) []byte { return append(data, walAdd) }, 2 * recLen, []string{"a", "b"}},
+		{"checksum", func(data []byte) []byte {
+			data[len(data)-1] ^= 0xff
+			return data
+		}, recLen, []string{"a"}},
+		{"zero bytes", func(data []byte) []byte { return append(data, make([]byte, 16)...) }, 2 * recLen, []string{"a", "b"}},
+		{"zero bytes after truncated", func(data []byte) []byte {
+			return append(data[:len(data)-3], make([]byte, 16)...)
+		}, recLen, []string{"a"}},
+	}
+	for _, test := range tests {
+		dir := t.TempDir()
+		d := openDurable[string](t, dir, nil, nil)
+		d.Update("a", "b")
+		d.Close()
+		path := filepath.Join(dir, walFile)
+		data, _ := os.ReadFile(path)
+		os.WriteFile(path, test.damage(data), 0o644)
+		d = openDurable[string](t, dir, nil, nil)
+		want := NewMapSet(test.want...)
+		if !d.Equal(want) {
+			t.Errorf("%s: got %v, want %v", test.name, d, want)
+		}
+		if size := fileSize(t, path); size != int64(test.good) {
+			t.Errorf("%s: got log size %d, want %d", test.name, size, test.good)
+		}
+		d.Add("c")
+		d.Close()
+		d = openDurable[string](t, dir, nil, nil)
+		if want.Add("c"); !d.Equal(want) {
+			t.Errorf("%s: got %v, want %v", test.name, d, want)
+		}
+		d.Close()
+	}
+}
+
+func TestDurableSetCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable[int](t, dir, nil, nil)
+	d.Update(1, 2, 3)
+	d.Close()
+	path := filepath.Join(dir, walFile)
+	data, _ := os.ReadFile(path)
+	data[2] ^= 0xff
+	os.WriteFile(path, data, 0o644)
+	if _, err := OpenDurableSet[int](dir, NewMapSet[int](), nil, nil); !errors.Is(err, ErrInvalidBinary) {
+		t.Errorf("got %v, want %v", err, ErrInvalidBinary)
+	}
+	os.WriteFile(filepath.Join(dir, snapshotFile), []byte{1, 2, 3, 4, 5}, 0o644)
+	if _, err := OpenDurableSet[int](dir, NewMapSet[int](), nil, nil); !errors.Is(err, ErrInvalidBinary) {
+		t.Errorf("got %v, want %v", err, ErrInvalidBinary)
+	}
+}
+
+func TestDurableSetCorruptLength(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable[string](t, dir, nil, nil)
+	d.Update("a", "b", "c")
+	d.Close()
+	path := filepath.Join(dir, walFile)
+	data, _ := os.ReadFile(path)
+	data[13] = 0x7f // length of the second record points past the end of the log
+	os.WriteFile(path, data, 0o644)
+	if _, err := OpenDurableSet[string](dir, NewMapSet[string](), nil, nil); !errors.Is(err, ErrInvalidBinary) {
+		t.Errorf("got %v, want %v", err, ErrInvalidBinary)
+	}
+	if size := fileSize(t, path); size != int64(len(data)) {
+		t.Errorf("got log size %d, want %d", size, len(data))
+	}
+}
+
+func TestDurableSetCodec(t *testing.T) {
+	dir := t.TempDir()
+	s := NewTreeSetFunc(func(a, b uint32) int { return int(b) - int(a) })
+	d, err := OpenDurableSet[uint32](dir, s, fixedCodec{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Update(1, 2, 3)
+	d.Compact()
+	d.Remove(2)
+	d.Close()
+	data, _ := os.ReadFile(filepath.Join(dir, walFile))
+	if got, want := binary.BigEndian.Uint32(data[6:]), uint32(2); got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+	d, err = OpenDurableSet[uint32](dir, s, fixedCodec{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if got, want := d.Elements(), []uint32{3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDurableSetGob(t *testing.T) {
+	type item struct{ A, B int }
+	dir := t.TempDir()
+	d := openDurable[item](t, dir, nil, nil)
+	d.Update(item{1, 2}, item{3, 4})
+	d.Remove(item{1, 2})
+	d.Close()
+	d = openDurable[item](t, dir, nil, nil)
+	defer d.Close()
+	if want := NewMapSet(item{3, 4}); !d.Equal(want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+}
+
+func TestDurableSetClosed(t *testing.T) {
+	d := openDurable[int](t, t.TempDir(), nil, nil)
+	d.Add(1)
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Close(); err != ErrClosed {
+		t.Errorf("got %v, want %v", err, ErrClosed)
+	}
+	if err := d.Err(); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	d.Add(2)
+	if !d.Contains(2) {
+		t.Error("element was not added")
+	}
+	if err := d.Err(); err != ErrClosed {
+		t.Errorf("got %v, want %v", err, ErrClosed)
+	}
+	if err := d.Compact(); err != ErrClosed {
+		t.Errorf("got %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestDurableSetInPlace(t *testing.T) {
+	dir := t.TempDir()
+	d := openDurable[int](t, dir, nil, nil)
+	d.Update(1, 2, 3)
+	if n := d.UnionWith(d); n != 0 {
+		t.Errorf("got %d, want 0", n)
+	}
+	if n := d.IntersectWith(NewMapSet(2, 3, 4)); n != 1 {
+		t.Errorf("got %d, want 1", n)
+	}
+	if n := d.DifferenceWith(NewMapSet(3)); n != 1 {
+		t.Errorf("got %d, want 1", n)
+	}
+	d.Add(5)
+	if elem, ok := d.Pop(); !ok || d.Contains(elem) {
+		t.Errorf("got %v and %v", elem, ok)
+	}
+	d.Close()
+	d2 := openDurable[int](t, dir, nil, nil)
+	defer d2.Close()
+	if !d2.Equal(d) {
+		t.Errorf("got %v, want %v", d2, d)
+	}
+	if n := d2.DifferenceWith(ReadOnly[int](d2)); n != 1 || !d2.IsEmpty() {
+		t.Errorf("got %d and %v, want 1 and empty set", n, d2)
+	}
+}
@@ -0,0 +1,89 @@
+package set
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetFlag is a command-line flag with a Set as value. It implements the
+// [flag.Value] and [flag.Getter] interfaces and the Type method of pflag.
+//
+// The flag can be given multiple times and each value can be a comma-separated
+// list of elements. The elements of the Set before the flag is set for the
+// first time are its default.
+type SetFlag[T any] struct {
+	set     Set[T]
+	parse   func(string) (T, error)
+	allowed ReadOnlySet[T]
+	changed bool
+}
+
+// NewSetFlag returns a new SetFlag that stores its elements in s. Function parse
+// converts the text of an element to the element; if it is nil, [ParseElement]
+// is used. If allowed is not nil, only its elements are accepted.
+func NewSetFlag[T any](s Set[T], parse func(string) (T, error), allowed ReadOnlySet[T]) *SetFlag[T] {
+	if parse == nil {
+		parse = ParseElement[T]
+	}
+	return &SetFlag[T]{set: s, parse: parse, allowed: allowed}
+}
+
+// String returns the elements of the Set as comma-separated list.
+func (f *SetFlag[T]) String() string {
+	if f == nil || f.set == nil {
+		return ""
+	}
+	return joinElements(f.set, ",")
+}
+
+// Set adds the elements of the comma-separated list value to the Set.
+// When it is called for the first time, the default elements are removed.
+func (f *SetFlag[T]) Set(value string) error {
+	var elems []T
+	for _, str := range strings.Split(value, ",") {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			continue
+		}
+		elem, err := f.parse(str)
+		if err != nil {
+			return fmt.Errorf("invalid element %q: %w", str, err)
+		}
+		if f.allowed != nil && !f.allowed.Contains(elem) {
+			return fmt.Errorf("invalid element %q (allowed: %s)", str, joinElements(f.allowed, ", "))
+		}
+		elems = append(elems, elem)
+	}
+	if !f.changed {
+		f.set.Clear()
+		f.changed = true
+	}
+	f.set.Update(elems...)
+	return nil
+}
+
+// joinElements returns the elements of s separated by sep.
+// The elements are sorted if the underlying type of T is ordered.
+func joinElements[T any](s ReadOnlySet[T], sep string) string {
+	elems := sortIfOrdered(s.Elements())
+	sl := make([]string, len(elems))
+	for i, elem := range elems {
+		sl[i] = FormatElement(elem)
+	}
+	return strings.Join(sl, sep)
+}
+
+// Get returns the Set.
+func (f *SetFlag[T]) Get() any {
+	return f.set
+}
+
+// Values returns the Set.
+func (f *SetFlag[T]) Values() Set[T] {
+	return f.set
+}
+
+// Type returns the name of the flag type for pflag.
+func (f *SetFlag[T]) Type() string {
+	return "set"
+}
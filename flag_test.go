@@ -0,0 +1,64 @@
+package set
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	include := NewSetFlag[string](NewTreeSet("default"), nil, nil)
+	ports := NewSetFlag[int](NewMapSet[int](), strconv.Atoi, nil)
+	fs.Var(include, "include", "tags to include")
+	fs.Var(ports, "port", "ports")
+	err := fs.Parse([]string{"-include", "a,b", "-include", " c ,, a", "-port", "80", "-port", "443,80"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := include.Values().Elements(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := ports.String(), "80,443"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := fs.Lookup("port").Value.(flag.Getter).Get(); !got.(Set[int]).Equal(NewMapSet(80, 443)) {
+		t.Errorf("got %v, want {80, 443}", got)
+	}
+	if err := fs.Parse([]string{"-port", "x"}); err == nil {
+		t.Error("got nil, want error")
+	}
+	timeouts := NewSetFlag[time.Duration](NewTreeSet(time.Second), nil, nil)
+	if err := timeouts.Set(timeouts.String()); err != nil {
+		t.Fatal(err)
+	}
+	if got := timeouts.Values(); !got.Equal(NewTreeSet(time.Second)) {
+		t.Errorf("got %v, want {1s}", got)
+	}
+}
+
+func TestSetFlagAllowed(t *testing.T) {
+	f := NewSetFlag[string](NewMapSet[string](), nil, NewMapSet("json", "text"))
+	if err := f.Set("json"); err != nil {
+		t.Fatal(err)
+	}
+	err := f.Set("json,xml")
+	if err == nil || !strings.Contains(err.Error(), "allowed: json, text") {
+		t.Errorf("got %v, want error", err)
+	}
+	if got, want := f.String(), "json"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if f.Type() != "set" {
+		t.Errorf("got %q, want %q", f.Type(), "set")
+	}
+	var zero SetFlag[string]
+	if got := zero.String(); got != "" {
+		t.Errorf("got %q, want empty string", got)
+	}
+}
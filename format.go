@@ -0,0 +1,174 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FormatLimit is the maximum number of elements that are printed with the verbs
+// %v and %s if no precision is given (see [MapSet.Format]), and the number of
+// elements that are logged as sample (see [MapSet.LogValue]).
+const FormatLimit = 10
+
+// formatTo writes the elements to f according to verb (see [MapSet.Format]).
+// Parameter n is the number of elements, ctor the Go syntax of the constructor
+// call up to the first element. Function elems returns an iterator over
+// at least the first k elements, or all elements if k is negative.
+func formatTo[T any](f fmt.State, verb rune, name, ctor string, n int, elems func(k int) iter.Seq[T]) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		sl := make([]string, 0, n)
+		for elem := range elems(-1) {
+			sl = append(sl, fmt.Sprintf("%#v", elem))
+		}
+		fmt.Fprintf(f, "%s%s)", ctor, strings.Join(sl, ", "))
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprint(f, formatSet(name, n, elems(-1)))
+	case verb == 'v' || verb == 's':
+		limit := FormatLimit
+		if p, ok := f.Precision(); ok {
+			limit = p
+		}
+		sl := make([]string, 0, min(n, limit)+1)
+		for elem := range elems(limit) {
+			if len(sl) == limit {
+				break
+			}
+			sl = append(sl, formatElement(elem))
+		}
+		if n > len(sl) {
+			sl = append(sl, fmt.Sprintf("… +%d", n-len(sl)))
+		}
+		fmt.Fprintf(f, "%s{%s}", name, strings.Join(sl, ", "))
+	default:
+		format := fmt.FormatString(f, verb)
+		sl := make([]string, 0, n)
+		for elem := range elems(-1) {
+			sl = append(sl, fmt.Sprintf(format, elem))
+		}
+		fmt.Fprintf(f, "%s{%s}", name, strings.Join(sl, ", "))
+	}
+}
+
+// logValue returns the cardinality n and the first [FormatLimit] elements as group.
+func logValue[T any](n int, elems iter.Seq[T]) slog.Value {
+	sample := make([]T, 0, min(n, FormatLimit))
+	for elem := range elems {
+		if len(sample) == FormatLimit {
+			break
+		}
+		sample = append(sample, elem)
+	}
+	return slog.GroupValue(slog.Int("cardinality", n), slog.Any("sample", sample))
+}
+
+// smallest returns the k smallest elements of seq in sorted order. It keeps
+// a max-heap of at most k elements, so it takes O(n log k) time.
+func smallest[T any](seq iter.Seq[T], k int, cmp func(T, T) int) []T {
+	h := make([]T, 0, k)
+	if k == 0 {
+		return h
+	}
+	for elem := range seq {
+		if len(h) < k {
+			h = append(h, elem)
+			for i := len(h) - 1; i > 0; {
+				p := (i - 1) / 2
+				if cmp(h[p], h[i]) >= 0 {
+					break
+				}
+				h[p], h[i] = h[i], h[p]
+				i = p
+			}
+		} else if cmp(elem, h[0]) < 0 {
+			h[0] = elem
+			for i := 0; ; {
+				c := 2*i + 1
+				if c >= k {
+					break
+				}
+				if c+1 < k && cmp(h[c+1], h[c]) > 0 {
+					c++
+				}
+				if cmp(h[i], h[c]) >= 0 {
+					break
+				}
+				h[i], h[c] = h[c], h[i]
+				i = c
+			}
+		}
+	}
+	slices.SortFunc(h, cmp)
+	return h
+}
+
+// first returns an iterator over the k smallest elements of the Set if the
+// underlying type of T is ordered, or over k arbitrary elements otherwise.
+// If k is negative or not less than the cardinality, it iterates over
+// all elements, which are sorted if T is ordered.
+func (s *MapSet[T]) first(k int) iter.Seq[T] {
+	if k < 0 || k >= len(s.m()) {
+		return slices.Values(sortIfOrdered(s.Elements()))
+	}
+	if cmp, ok := orderedCmp[T](); ok {
+		return slices.Values(smallest(s.Iter(), k, cmp))
+	}
+	return s.Iter()
+}
+
+// Format implements the [fmt.Formatter] interface. The elements are sorted
+// if the underlying type of T is ordered.
+//
+// The verbs %v and %s print at most [FormatLimit] elements, or as many
+// as the precision says (e.g. %.3v), followed by the number of omitted elements:
+//
+//	MapSet{1, 2, 3, … +9997}
+//
+// They select the k smallest elements without sorting the whole Set,
+// which takes O(n log k) time.
+//
+// %+v prints all elements like [MapSet.String], %#v prints Go syntax:
+//
+//	set.NewMapSet[int](1, 2, 3)
+//
+// All other verbs are applied to each element.
+func (s *MapSet[T]) Format(f fmt.State, verb rune) {
+	ctor := fmt.Sprintf("set.NewMapSet[%s](", reflect.TypeFor[T]())
+	formatTo(f, verb, "MapSet", ctor, len(s.m()), s.first)
+}
+
+// LogValue implements the [slog.LogValuer] interface. It returns a group with
+// the cardinality and a sample of at most [FormatLimit] elements. The sample
+// consists of the smallest elements if the underlying type of T is ordered
+// (see [MapSet.Format]), and of arbitrary elements otherwise.
+func (s *MapSet[T]) LogValue() slog.Value {
+	return logValue(len(s.m()), s.first(FormatLimit))
+}
+
+// Format implements the [fmt.Formatter] interface (see [MapSet.Format]).
+// For a TreeSet with a custom comparison function %#v prints
+// a call of [NewTreeSetFunc].
+func (s *TreeSet[T]) Format(f fmt.State, verb rune) {
+	cmp, ordered := s.comparator()
+	var ctor string
+	if ordered {
+		ctor = fmt.Sprintf("set.NewTreeSet[%s](", reflect.TypeFor[T]())
+	} else {
+		// like %#v of a func value
+		ctor = fmt.Sprintf("set.NewTreeSetFunc[%[1]s]((func(%[1]s, %[1]s) int)(%#[2]x), ",
+			reflect.TypeFor[T](), reflect.ValueOf(cmp).Pointer())
+	}
+	tree := s.t()
+	formatTo(f, verb, "TreeSet", ctor, tree.Count(), func(int) iter.Seq[T] { return tree.Iter() })
+}
+
+// LogValue implements the [slog.LogValuer] interface. It returns a group with
+// the cardinality and a sample of at most [FormatLimit] elements.
+func (s *TreeSet[T]) LogValue() slog.Value {
+	tree := s.t()
+	return logValue(tree.Count(), tree.Iter())
+}
@@ -0,0 +1,124 @@
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var (
+	_ fmt.Formatter  = (*MapSet[int])(nil)
+	_ fmt.Formatter  = (*TreeSet[int])(nil)
+	_ slog.LogValuer = (*MapSet[int])(nil)
+	_ slog.LogValuer = (*TreeSet[int])(nil)
+)
+
+func TestFormat(t *testing.T) {
+	var big []int
+	for i := 1; i <= 10000; i++ {
+		big = append(big, i)
+	}
+	var tests = []struct {
+		format string
+		arg    any
+		want   string
+	}{
+		{"%v", NewMapSet[int](), "MapSet{}"},
+		{"%v", NewMapSet(3, 1, 2), "MapSet{1, 2, 3}"},
+		{"%s", NewTreeSet("b", " a", "x,y"), `TreeSet{" a", b, "x,y"}`},
+		{"%v", NewTreeSet(big...), "TreeSet{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, … +9990}"},
+		{"%.3v", NewMapSet(big...), "MapSet{1, 2, 3, … +9997}"},
+		{"%.0v", NewMapSet(1, 2), "MapSet{… +2}"},
+		{"%+v", NewMapSet(big[:12]...), "MapSet{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}"},
+		{"%#v", NewMapSet(2, 1), "set.NewMapSet[int](1, 2)"},
+		{"%#v", NewTreeSet("a", "b"), `set.NewTreeSet[string]("a", "b")`},
+		{"%#v", NewMapSet[string](), "set.NewMapSet[string]()"},
+		{"%d", NewTreeSet(10, 2), "TreeSet{2, 10}"},
+		{"%x", NewTreeSet(10, 255), "TreeSet{a, ff}"},
+		{"%q", NewMapSet("b", "a"), `MapSet{"a", "b"}`},
+		{"%v", (*MapSet[int])(nil), "MapSet{}"},
+		{"%v", &TreeSet[int]{}, "TreeSet{}"},
+	}
+	for i, test := range tests {
+		if got := fmt.Sprintf(test.format, test.arg); got != test.want {
+			t.Errorf("%d: got %s, want %s", i, got, test.want)
+		}
+	}
+	got := fmt.Sprintf("%#v", NewTreeSetFunc(func(a, b int) int { return b - a }, 1, 2))
+	if want := "set.NewTreeSetFunc[int]((func(int, int) int)(0x"; !strings.HasPrefix(got, want) || !strings.HasSuffix(got, "), 2, 1)") {
+		t.Errorf("got %s, want %s…), 2, 1)", got, want)
+	}
+}
+
+func TestFormatPrecision(t *testing.T) {
+	s := NewTreeSet(1, 2, 3)
+	var tests = []struct {
+		format string
+		want   string
+	}{
+		{"%.2v", "TreeSet{1, 2, … +1}"},
+		{"%.2s", "TreeSet{1, 2, … +1}"},
+		{"%.3v", "TreeSet{1, 2, 3}"},
+		{"%.9v", "TreeSet{1, 2, 3}"},
+	}
+	for i, test := range tests {
+		if got := fmt.Sprintf(test.format, s); got != test.want {
+			t.Errorf("%d: got %s, want %s", i, got, test.want)
+		}
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	var tests = []struct {
+		arg  any
+		want string
+	}{
+		{NewMapSet(3, 1, 2), "s.cardinality=3 s.sample=\"[1 2 3]\""},
+		{NewTreeSet("a"), "s.cardinality=1 s.sample=[a]"},
+		{NewTreeSet(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11), "s.cardinality=11 s.sample=\"[1 2 3 4 5 6 7 8 9 10]\""},
+		{&MapSet[int]{}, "s.cardinality=0 s.sample=[]"},
+	}
+	for i, test := range tests {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && a.Key != "s" {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+		logger.Info("", "s", test.arg)
+		if got := strings.TrimSpace(buf.String()); got != test.want {
+			t.Errorf("%d: got %s, want %s", i, got, test.want)
+		}
+	}
+}
+
+func TestSmallest(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, n := range []int{0, 1, 5, 100, 1000} {
+		elems := r.Perm(n)
+		for _, k := range []int{0, 1, 3, 10, n} {
+			k = min(k, n)
+			got := smallest(slices.Values(elems), k, cmp.Compare[int])
+			want := slices.Sorted(slices.Values(elems))[:k]
+			if !slices.Equal(got, want) {
+				t.Errorf("n=%d, k=%d: got %v, want %v", n, k, got, want)
+			}
+		}
+	}
+}
+
+func TestFormatUnordered(t *testing.T) {
+	type pair struct{ a, b int }
+	s := NewMapSet(pair{1, 2}, pair{3, 4}, pair{5, 6})
+	if got := fmt.Sprintf("%.1v", s); !strings.HasPrefix(got, `MapSet{"{`) || !strings.HasSuffix(got, `}", … +2}`) {
+		t.Errorf("got %s, want one element and … +2", got)
+	}
+}
@@ -0,0 +1,222 @@
+package set
+
+import (
+	"errors"
+	"iter"
+)
+
+// ErrFrozen is returned by [TryAdd] and [TryRemove] if the Set is a [FrozenSet].
+var ErrFrozen = errors.New("set: set is frozen")
+
+// FrozenSet is an immutable [Set]. All methods that modify the Set panic with [ErrFrozen].
+// All other methods delegate to the frozen Set.
+type FrozenSet[T any] struct {
+	s Set[T]
+}
+
+// Freeze returns a FrozenSet with a copy of the elements of s.
+func (s *MapSet[T]) Freeze() *FrozenSet[T] {
+	return &FrozenSet[T]{s: s.Clone()}
+}
+
+// Freeze returns a FrozenSet with a copy of the elements of s.
+func (s *TreeSet[T]) Freeze() *FrozenSet[T] {
+	return &FrozenSet[T]{s: s.Clone()}
+}
+
+// IsFrozen reports whether s is a [FrozenSet].
+func IsFrozen[T any](s ReadOnlySet[T]) bool {
+	_, ok := s.(*FrozenSet[T])
+	return ok
+}
+
+// TryAdd adds an element to s. It returns [ErrFrozen] if s is a [FrozenSet].
+func TryAdd[T any](s Set[T], elem T) (bool, error) {
+	if IsFrozen[T](s) {
+		return false, ErrFrozen
+	}
+	return s.Add(elem), nil
+}
+
+// TryRemove removes an element from s. It returns [ErrFrozen] if s is a [FrozenSet].
+func TryRemove[T any](s Set[T], elem T) (bool, error) {
+	if IsFrozen[T](s) {
+		return false, ErrFrozen
+	}
+	return s.Remove(elem), nil
+}
+
+// Contains reports whether the element is in the Set.
+func (s *FrozenSet[T]) Contains(elem T) bool {
+	return s.s.Contains(elem)
+}
+
+// Add panics with ErrFrozen.
+func (s *FrozenSet[T]) Add(elem T) bool {
+	panic(ErrFrozen)
+}
+
+// Update panics with ErrFrozen.
+func (s *FrozenSet[T]) Update(elems ...T) {
+	panic(ErrFrozen)
+}
+
+// UpdateSeq panics with ErrFrozen.
+func (s *FrozenSet[T]) UpdateSeq(seq iter.Seq[T]) {
+	panic(ErrFrozen)
+}
+
+// Remove panics with ErrFrozen.
+func (s *FrozenSet[T]) Remove(elem T) bool {
+	panic(ErrFrozen)
+}
+
+// Clear panics with ErrFrozen.
+func (s *FrozenSet[T]) Clear() {
+	panic(ErrFrozen)
+}
+
+// Pop panics with ErrFrozen.
+func (s *FrozenSet[T]) Pop() (T, bool) {
+	panic(ErrFrozen)
+}
+
+// RemoveIf panics with ErrFrozen.
+func (s *FrozenSet[T]) RemoveIf(pred func(T) bool) int {
+	panic(ErrFrozen)
+}
+
+// RetainIf panics with ErrFrozen.
+func (s *FrozenSet[T]) RetainIf(pred func(T) bool) int {
+	panic(ErrFrozen)
+}
+
+// UnionWith panics with ErrFrozen.
+func (s *FrozenSet[T]) UnionWith(s2 ReadOnlySet[T]) int {
+	panic(ErrFrozen)
+}
+
+// IntersectWith panics with ErrFrozen.
+func (s *FrozenSet[T]) IntersectWith(s2 ReadOnlySet[T]) int {
+	panic(ErrFrozen)
+}
+
+// DifferenceWith panics with ErrFrozen.
+func (s *FrozenSet[T]) DifferenceWith(s2 ReadOnlySet[T]) int {
+	panic(ErrFrozen)
+}
+
+// SymDifferenceWith panics with ErrFrozen.
+func (s *FrozenSet[T]) SymDifferenceWith(s2 ReadOnlySet[T]) int {
+	panic(ErrFrozen)
+}
+
+// IsEmpty returns true if Set is an empty set.
+func (s *FrozenSet[T]) IsEmpty() bool {
+	return s.s.IsEmpty()
+}
+
+// Cardinality returns the number of elements in the Set.
+func (s *FrozenSet[T]) Cardinality() int {
+	return s.s.Cardinality()
+}
+
+// Any returns an arbitrary element of the Set.
+// Returns false if the Set is empty.
+func (s *FrozenSet[T]) Any() (T, bool) {
+	return s.s.Any()
+}
+
+// Union returns a new Set which is the union of s and s2.
+// The result is not frozen.
+func (s *FrozenSet[T]) Union(s2 ReadOnlySet[T]) Set[T] {
+	return s.s.Union(s2)
+}
+
+// Intersection returns a new Set which is the intersection of s and s2.
+// The result is not frozen.
+func (s *FrozenSet[T]) Intersection(s2 ReadOnlySet[T]) Set[T] {
+	return s.s.Intersection(s2)
+}
+
+// Difference returns a new Set which is the set difference of s and s2.
+// The result is not frozen.
+func (s *FrozenSet[T]) Difference(s2 ReadOnlySet[T]) Set[T] {
+	return s.s.Difference(s2)
+}
+
+// SymDifference returns a new Set which is the symmetric difference of s and s2.
+// The result is not frozen.
+func (s *FrozenSet[T]) SymDifference(s2 ReadOnlySet[T]) Set[T] {
+	return s.s.SymDifference(s2)
+}
+
+// IntersectionCardinality returns the cardinality of the intersection of s and s2.
+func (s *FrozenSet[T]) IntersectionCardinality(s2 ReadOnlySet[T]) int {
+	return s.s.IntersectionCardinality(s2)
+}
+
+// UnionCardinality returns the cardinality of the union of s and s2.
+func (s *FrozenSet[T]) UnionCardinality(s2 ReadOnlySet[T]) int {
+	return s.s.UnionCardinality(s2)
+}
+
+// DifferenceCardinality returns the cardinality of the set difference of s and s2.
+func (s *FrozenSet[T]) DifferenceCardinality(s2 ReadOnlySet[T]) int {
+	return s.s.DifferenceCardinality(s2)
+}
+
+// IsDisjoint returns true if s and s2 have no elements in common.
+func (s *FrozenSet[T]) IsDisjoint(s2 ReadOnlySet[T]) bool {
+	return s.s.IsDisjoint(s2)
+}
+
+// Overlaps returns true if s and s2 have at least one element in common.
+func (s *FrozenSet[T]) Overlaps(s2 ReadOnlySet[T]) bool {
+	return s.s.Overlaps(s2)
+}
+
+// IsSubset returns true if s is a subset of s2.
+func (s *FrozenSet[T]) IsSubset(s2 ReadOnlySet[T]) bool {
+	return s.s.IsSubset(s2)
+}
+
+// IsProperSubset returns true if s is a proper subset of s2.
+func (s *FrozenSet[T]) IsProperSubset(s2 ReadOnlySet[T]) bool {
+	return s.s.IsProperSubset(s2)
+}
+
+// IsSuperset returns true if s is a superset of s2.
+func (s *FrozenSet[T]) IsSuperset(s2 ReadOnlySet[T]) bool {
+	return s.s.IsSuperset(s2)
+}
+
+// IsProperSuperset returns true if s is a proper superset of s2.
+func (s *FrozenSet[T]) IsProperSuperset(s2 ReadOnlySet[T]) bool {
+	return s.s.IsProperSuperset(s2)
+}
+
+// Equal returns true if s and s2 contain the same elements.
+func (s *FrozenSet[T]) Equal(s2 ReadOnlySet[T]) bool {
+	return s.s.Equal(s2)
+}
+
+// Clone returns a copy of the Set that is not frozen.
+func (s *FrozenSet[T]) Clone() Set[T] {
+	return s.s.Clone()
+}
+
+// Elements returns a slice with all elements of the Set.
+func (s *FrozenSet[T]) Elements() []T {
+	return s.s.Elements()
+}
+
+// Iter returns an iterator over all elements of the Set.
+func (s *FrozenSet[T]) Iter() iter.Seq[T] {
+	return s.s.Iter()
+}
+
+// String returns a string representation of the Set.
+func (s *FrozenSet[T]) String() string {
+	return s.s.String()
+}
@@ -0,0 +1,71 @@
+package set
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	var tests = []Set[int]{
+		NewMapSet(1, 2).Freeze(),
+		NewTreeSet(1, 2).Freeze(),
+	}
+	for i, s := range tests {
+		if !IsFrozen[int](s) {
+			t.Errorf("%d: got false, want true", i)
+		}
+		if _, err := TryAdd(s, 3); !errors.Is(err, ErrFrozen) {
+			t.Errorf("%d: got %v, want %v", i, err, ErrFrozen)
+		}
+		if _, err := TryRemove(s, 1); !errors.Is(err, ErrFrozen) {
+			t.Errorf("%d: got %v, want %v", i, err, ErrFrozen)
+		}
+		if !s.Equal(NewTreeSet(1, 2)) {
+			t.Errorf("%d: got %v, want {1, 2}", i, s)
+		}
+		c := s.Clone()
+		if IsFrozen(c) {
+			t.Errorf("%d: clone is frozen", i)
+		}
+		if !c.Add(3) {
+			t.Errorf("%d: got false, want true", i)
+		}
+	}
+}
+
+func TestFreezeCopies(t *testing.T) {
+	s := NewMapSet(1)
+	f := s.Freeze()
+	s.Add(2)
+	if f.Contains(2) {
+		t.Error("got true, want false")
+	}
+}
+
+func TestFrozenPanics(t *testing.T) {
+	var tests = []func(s Set[int]){
+		func(s Set[int]) { s.Add(1) },
+		func(s Set[int]) { s.Update(1) },
+		func(s Set[int]) { s.Remove(1) },
+	}
+	for i, test := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != ErrFrozen {
+					t.Errorf("%d: got %v, want %v", i, r, ErrFrozen)
+				}
+			}()
+			test(NewMapSet(1).Freeze())
+		}()
+	}
+}
+
+func TestTryAdd(t *testing.T) {
+	s := NewMapSet[int]()
+	if ok, err := TryAdd[int](s, 1); !ok || err != nil {
+		t.Errorf("got %t and %v, want true and nil", ok, err)
+	}
+	if ok, err := TryRemove[int](s, 1); !ok || err != nil {
+		t.Errorf("got %t and %v, want true and nil", ok, err)
+	}
+}
@@ -0,0 +1,73 @@
+package set
+
+// Filter returns a new Set with all elements of s for which pred returns true.
+// The result has the implementation of s.
+func Filter[T any](s ReadOnlySet[T], pred func(T) bool) Set[T] {
+	result := s.Clone()
+	result.RetainIf(pred)
+	return result
+}
+
+// Map adds f(elem) for all elements of s to dst and returns dst.
+func Map[T, U any](s ReadOnlySet[T], f func(T) U, dst Set[U]) Set[U] {
+	for elem := range s.Iter() {
+		dst.Add(f(elem))
+	}
+	return dst
+}
+
+// Reduce calls f for all elements of s with the result of the previous call,
+// starting with init, and returns the result of the last call.
+func Reduce[T, A any](s ReadOnlySet[T], init A, f func(A, T) A) A {
+	acc := init
+	for elem := range s.Iter() {
+		acc = f(acc, elem)
+	}
+	return acc
+}
+
+// Partition returns two new sets: one with all elements of s for which pred
+// returns true and one with all others. The results have the implementation of s.
+func Partition[T any](s ReadOnlySet[T], pred func(T) bool) (Set[T], Set[T]) {
+	in := Filter(s, pred)
+	return in, s.Difference(in)
+}
+
+// Any returns true if pred returns true for at least one element of s.
+func Any[T any](s ReadOnlySet[T], pred func(T) bool) bool {
+	_, ok := Find(s, pred)
+	return ok
+}
+
+// All returns true if pred returns true for all elements of s.
+func All[T any](s ReadOnlySet[T], pred func(T) bool) bool {
+	for elem := range s.Iter() {
+		if !pred(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of elements of s for which pred returns true.
+func Count[T any](s ReadOnlySet[T], pred func(T) bool) int {
+	n := 0
+	for elem := range s.Iter() {
+		if pred(elem) {
+			n++
+		}
+	}
+	return n
+}
+
+// Find returns an element of s for which pred returns true.
+// Returns false if there is no such element.
+func Find[T any](s ReadOnlySet[T], pred func(T) bool) (T, bool) {
+	for elem := range s.Iter() {
+		if pred(elem) {
+			return elem, true
+		}
+	}
+	var zero T
+	return zero, false
+}
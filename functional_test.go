@@ -0,0 +1,94 @@
+package set
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func isEven(n int) bool {
+	return n%2 == 0
+}
+
+func TestFilter(t *testing.T) {
+	got := Filter[int](NewMapSet(1, 2, 3, 4), isEven)
+	if _, ok := got.(*MapSet[int]); !ok {
+		t.Errorf("got %T, want *MapSet[int]", got)
+	}
+	if !got.Equal(NewTreeSet(2, 4)) {
+		t.Errorf("got %v, want {2, 4}", got)
+	}
+}
+
+func TestMap(t *testing.T) {
+	got := Map[int](NewTreeSet(1, 2, 10), strconv.Itoa, NewTreeSet[string]())
+	if want := []string{"1", "10", "2"}; !reflect.DeepEqual(got.Elements(), want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := Reduce[int](NewMapSet(1, 2, 3), 0, func(acc, n int) int {
+		return acc + n
+	})
+	if sum != 6 {
+		t.Errorf("got %d, want 6", sum)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	in, out := Partition[int](NewTreeSet(1, 2, 3, 4, 5), isEven)
+	if want := []int{2, 4}; !reflect.DeepEqual(in.Elements(), want) {
+		t.Errorf("got %v, want %v", in, want)
+	}
+	if want := []int{1, 3, 5}; !reflect.DeepEqual(out.Elements(), want) {
+		t.Errorf("got %v, want %v", out, want)
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	var tests = []struct {
+		s        ReadOnlySet[int]
+		any, all bool
+		count    int
+	}{
+		{NewMapSet[int](), false, true, 0},
+		{NewMapSet(1, 3), false, false, 0},
+		{NewMapSet(1, 2), true, false, 1},
+		{NewTreeSet(2, 4), true, true, 2},
+	}
+	for i, test := range tests {
+		if got := Any(test.s, isEven); got != test.any {
+			t.Errorf("%d: got Any %t, want %t", i, got, test.any)
+		}
+		if got := All(test.s, isEven); got != test.all {
+			t.Errorf("%d: got All %t, want %t", i, got, test.all)
+		}
+		if got := Count(test.s, isEven); got != test.count {
+			t.Errorf("%d: got Count %d, want %d", i, got, test.count)
+		}
+		if elem, ok := Find(test.s, isEven); ok != test.any || ok && !isEven(elem) {
+			t.Errorf("%d: got Find %d and %t", i, elem, ok)
+		}
+	}
+}
+
+func TestRemoveIf(t *testing.T) {
+	for i, s := range []Set[int]{NewMapSet(1, 2, 3, 4), NewTreeSet(1, 2, 3, 4)} {
+		if n := s.RemoveIf(isEven); n != 2 {
+			t.Errorf("%d: got %d, want 2", i, n)
+		}
+		got := s.Elements()
+		sort.Ints(got)
+		if want := []int{1, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: got %v, want %v", i, got, want)
+		}
+		if n := s.RetainIf(func(n int) bool { return n > 1 }); n != 1 {
+			t.Errorf("%d: got %d, want 1", i, n)
+		}
+		if got, want := s.Elements(), []int{3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: got %v, want %v", i, got, want)
+		}
+	}
+}
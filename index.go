@@ -0,0 +1,35 @@
+package set
+
+// GroupBy groups the elements of s by key. Function newSet is called to create
+// the Set for each group, e.g. to choose between [MapSet] and [TreeSet].
+func GroupBy[T any, K comparable](s ReadOnlySet[T], key func(T) K, newSet func() Set[T]) map[K]Set[T] {
+	result := make(map[K]Set[T])
+	for elem := range s.Iter() {
+		k := key(elem)
+		group, ok := result[k]
+		if !ok {
+			group = newSet()
+			result[k] = group
+		}
+		group.Add(elem)
+	}
+	return result
+}
+
+// Invert turns a map from keys to sets of values into a map from values to sets
+// of keys, e.g. a map from roles to users into a map from users to roles.
+// Function newSet is called to create the Set for each value.
+func Invert[K, V comparable](m map[K]Set[V], newSet func() Set[K]) map[V]Set[K] {
+	result := make(map[V]Set[K])
+	for k, s := range m {
+		for v := range s.Iter() {
+			keys, ok := result[v]
+			if !ok {
+				keys = newSet()
+				result[v] = keys
+			}
+			keys.Add(k)
+		}
+	}
+	return result
+}
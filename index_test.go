@@ -0,0 +1,49 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	s := NewMapSet("apple", "avocado", "banana", "cherry", "chestnut")
+	got := GroupBy[string](s, func(s string) byte { return s[0] }, func() Set[string] {
+		return NewTreeSet[string]()
+	})
+	want := map[byte][]string{
+		'a': {"apple", "avocado"},
+		'b': {"banana"},
+		'c': {"cherry", "chestnut"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(got), len(want))
+	}
+	for k, v := range want {
+		if elems := got[k].Elements(); !reflect.DeepEqual(elems, v) {
+			t.Errorf("%c: got %v, want %v", k, elems, v)
+		}
+	}
+}
+
+func TestInvert(t *testing.T) {
+	m := map[string]Set[string]{
+		"admin": NewMapSet("alice"),
+		"dev":   NewMapSet("alice", "bob"),
+		"ops":   NewTreeSet[string](),
+	}
+	got := Invert(m, func() Set[string] {
+		return NewTreeSet[string]()
+	})
+	want := map[string][]string{
+		"alice": {"admin", "dev"},
+		"bob":   {"dev"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+	for k, v := range want {
+		if elems := got[k].Elements(); !reflect.DeepEqual(elems, v) {
+			t.Errorf("%s: got %v, want %v", k, elems, v)
+		}
+	}
+}
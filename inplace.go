@@ -0,0 +1,79 @@
+package set
+
+import "reflect"
+
+// same reports whether s2 is the Set s or a view of it. Sets whose dynamic
+// type is not comparable are never the same.
+func same[T any](s Set[T], s2 ReadOnlySet[T]) bool {
+	x, y := unwrap[T](s), unwrap(s2)
+	t := reflect.TypeOf(x)
+	return t != nil && t == reflect.TypeOf(y) && t.Comparable() && x == y
+}
+
+// UnionInto replaces the elements of dst with the union of a and b.
+// Both a and b may be dst.
+func UnionInto[T any](dst Set[T], a, b ReadOnlySet[T]) {
+	switch {
+	case same(dst, a):
+		dst.UnionWith(b)
+	case same(dst, b):
+		dst.UnionWith(a)
+	default:
+		dst.Clear()
+		dst.UnionWith(a)
+		dst.UnionWith(b)
+	}
+}
+
+// IntersectionInto replaces the elements of dst with the intersection of a and b.
+// Both a and b may be dst.
+func IntersectionInto[T any](dst Set[T], a, b ReadOnlySet[T]) {
+	switch {
+	case same(dst, a):
+		dst.IntersectWith(b)
+	case same(dst, b):
+		dst.IntersectWith(a)
+	default:
+		dst.Clear()
+		for elem := range a.Iter() {
+			if b.Contains(elem) {
+				dst.Add(elem)
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the elements of dst with the set difference of a and b.
+// Both a and b may be dst.
+func DifferenceInto[T any](dst Set[T], a, b ReadOnlySet[T]) {
+	switch {
+	case same(dst, a):
+		dst.DifferenceWith(b)
+	case same(dst, b):
+		diff := a.Difference(b)
+		dst.Clear()
+		dst.UnionWith(diff)
+	default:
+		dst.Clear()
+		for elem := range a.Iter() {
+			if !b.Contains(elem) {
+				dst.Add(elem)
+			}
+		}
+	}
+}
+
+// SymDifferenceInto replaces the elements of dst with the symmetric difference of a and b.
+// Both a and b may be dst.
+func SymDifferenceInto[T any](dst Set[T], a, b ReadOnlySet[T]) {
+	switch {
+	case same(dst, a):
+		dst.SymDifferenceWith(b)
+	case same(dst, b):
+		dst.SymDifferenceWith(a)
+	default:
+		dst.Clear()
+		dst.UnionWith(a)
+		dst.SymDifferenceWith(b)
+	}
+}
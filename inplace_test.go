@@ -0,0 +1,69 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInto(t *testing.T) {
+	var tests = []struct {
+		f    func(dst Set[int], a, b ReadOnlySet[int])
+		want []int
+	}{
+		{UnionInto[int], []int{1, 2, 3, 4}},
+		{IntersectionInto[int], []int{2, 3}},
+		{DifferenceInto[int], []int{1}},
+		{SymDifferenceInto[int], []int{1, 4}},
+	}
+	for i, test := range tests {
+		dst := NewTreeSet(7, 8, 9)
+		test.f(dst, NewMapSet(1, 2, 3), NewTreeSet(2, 3, 4))
+		if got := dst.Elements(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+		a := NewTreeSet(1, 2, 3)
+		test.f(a, a, NewMapSet(2, 3, 4))
+		if got := a.Elements(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+		b := NewTreeSet(2, 3, 4)
+		test.f(b, ReadOnly[int](NewMapSet(1, 2, 3)), ReadOnly[int](b))
+		if got := b.Elements(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+// sliceSet is a Set whose dynamic type is not comparable.
+type sliceSet struct {
+	*MapSet[int]
+	tags []string
+}
+
+func TestIntoUncomparable(t *testing.T) {
+	var tests = []struct {
+		f    func(dst Set[int], a, b ReadOnlySet[int])
+		want []int
+	}{
+		{UnionInto[int], []int{1, 2, 3, 4}},
+		{IntersectionInto[int], []int{2, 3}},
+		{DifferenceInto[int], []int{1}},
+		{SymDifferenceInto[int], []int{1, 4}},
+	}
+	for i, test := range tests {
+		tags := []string{"x"}
+		// not detected as the same set, but must not panic
+		a := sliceSet{NewMapSet(1, 2, 3), tags}
+		test.f(a, a, NewMapSet(2, 3, 4))
+		b := sliceSet{NewMapSet(2, 3, 4), tags}
+		test.f(b, NewMapSet(1, 2, 3), ReadOnly[int](sliceSet{NewMapSet(2, 3, 4), tags}))
+		if got := b.SortedElements(nil); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+		dst := sliceSet{NewMapSet(7), tags}
+		test.f(dst, NewMapSet(1, 2, 3), sliceSet{NewMapSet(2, 3, 4), tags})
+		if got := dst.SortedElements(nil); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package set
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicate is returned by [DecodeJSON] in strict mode if an element occurs more than once.
+var ErrDuplicate = errors.New("set: duplicate element")
+
+// MarshalJSON implements the [json.Marshaler] interface. The Set is encoded
+// as a JSON array. The elements are sorted if the underlying type of T is ordered.
+// The value receiver makes it work for sets that are struct fields by value.
+func (s MapSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sortIfOrdered(s.Elements()))
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface. The elements of
+// the Set are replaced by the elements of the JSON array; null is a no-op.
+// Duplicate elements are ignored; use [DecodeJSON] to reject them.
+func (s *MapSet[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON[T](data, s)
+}
+
+// MarshalJSON implements the [json.Marshaler] interface. The Set is encoded
+// as a JSON array in the order of the Set.
+func (s TreeSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Elements())
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface. The elements of
+// the Set are replaced by the elements of the JSON array; null is a no-op.
+// Duplicate elements are ignored; use [DecodeJSON] to reject them.
+func (s *TreeSet[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON[T](data, s)
+}
+
+func unmarshalJSON[T any](data []byte, s Set[T]) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s.Clear()
+	return DecodeJSON(json.NewDecoder(bytes.NewReader(data)), s, false)
+}
+
+// DecodeJSON reads the next JSON array from dec and adds its elements to s.
+// The elements are decoded one by one without building an intermediate slice.
+// If strict is true, an element that is already in s is an error that wraps
+// [ErrDuplicate]. A JSON null adds nothing.
+func DecodeJSON[T any](dec *json.Decoder, s Set[T], strict bool) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("set: expected JSON array at offset %d, got %v", dec.InputOffset(), tok)
+	}
+	for dec.More() {
+		var elem T
+		if err := dec.Decode(&elem); err != nil {
+			return err
+		}
+		if !s.Add(elem) && strict {
+			return fmt.Errorf("%w %v before offset %d", ErrDuplicate, elem, dec.InputOffset())
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
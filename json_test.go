@@ -0,0 +1,92 @@
+package set
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	var tests = []struct {
+		v    any
+		want string
+	}{
+		{NewMapSet[int](), `[]`},
+		{NewMapSet(3, 1, 2), `[1,2,3]`},
+		{NewMapSet("b", "a"), `["a","b"]`},
+		{NewTreeSet(3, 1, 2), `[1,2,3]`},
+		{struct {
+			Tags *MapSet[string] `json:"tags"`
+		}{NewMapSet("x")}, `{"tags":["x"]}`},
+	}
+	for i, test := range tests {
+		got, err := json.Marshal(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != test.want {
+			t.Errorf("%d: got %s, want %s", i, got, test.want)
+		}
+	}
+}
+
+func TestMarshalJSONValue(t *testing.T) {
+	var cfg struct {
+		M MapSet[int]
+		T TreeSet[string]
+		Z MapSet[int]
+	}
+	cfg.M.Update(2, 1)
+	cfg.T.Update("b", "a")
+	got, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"M":[1,2],"T":["a","b"],"Z":[]}`; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var v struct {
+		M MapSet[int]
+		T TreeSet[string]
+	}
+	v.M.Add(42)
+	err := json.Unmarshal([]byte(`{"M": [1, 2, 1], "T": ["b", "a"]}`), &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int]struct{}{1: {}, 2: {}}; !reflect.DeepEqual(v.M.data, want) {
+		t.Errorf("got %v, want %v", v.M.data, want)
+	}
+	if got, want := v.T.Elements(), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := json.Unmarshal([]byte(`{"M": {}}`), &v); err == nil {
+		t.Error("got nil, want error")
+	}
+	if err := json.Unmarshal([]byte(`{"M": ["x"]}`), &v); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`[1, 2, 3] null [1, 1]`))
+	s := NewTreeSet[int]()
+	if err := DecodeJSON[int](dec, s, true); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Elements(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := DecodeJSON[int](dec, s, true); err != nil {
+		t.Fatal(err)
+	}
+	s.Clear()
+	if err := DecodeJSON[int](dec, s, true); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("got %v, want %v", err, ErrDuplicate)
+	}
+}
@@ -0,0 +1,186 @@
+package set
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// LineOptions configures [ReadLines] and [ReadCSV]. A nil *LineOptions
+// is the same as the zero value.
+type LineOptions struct {
+	Comment string // lines starting with Comment are skipped; empty means no comments (one character for ReadCSV)
+	Trim    bool   // leading and trailing white space is removed from elements
+	Header  bool   // the first record is a header and skipped (only ReadCSV)
+}
+
+// ReadStats contains statistics of [ReadLines] and [ReadCSV].
+type ReadStats struct {
+	Lines      int // number of lines or records read
+	Added      int // number of elements added to the Set
+	Duplicates int // number of elements that already were in the Set
+	Skipped    int // number of empty, comment and header lines
+}
+
+// LineError is returned if a line or record cannot be parsed.
+type LineError struct {
+	Line int   // line number starting at 1
+	Err  error // the underlying error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("set: line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// lineAdder returns a function that parses str, adds the element to dst
+// and updates stats.
+func lineAdder[T any](dst Set[T], parse func(string) (T, error), stats *ReadStats) func(str string, line int) error {
+	if parse == nil {
+		parse = ParseElement[T]
+	}
+	return func(str string, line int) error {
+		elem, err := parse(str)
+		if err != nil {
+			return &LineError{Line: line, Err: err}
+		}
+		if dst.Add(elem) {
+			stats.Added++
+		} else {
+			stats.Duplicates++
+		}
+		return nil
+	}
+}
+
+// ReadLines reads r line by line and adds an element for each line to dst.
+// Empty lines and lines starting with opts.Comment are skipped. Function parse
+// converts a line to the element; if it is nil, [ParseElement] is used.
+// If a line cannot be parsed, a [*LineError] is returned. The returned
+// statistics include all lines read before an error.
+func ReadLines[T any](r io.Reader, dst Set[T], parse func(string) (T, error), opts *LineOptions) (ReadStats, error) {
+	if opts == nil {
+		opts = &LineOptions{}
+	}
+	var stats ReadStats
+	add := lineAdder(dst, parse, &stats)
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line == "" && err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return stats, err
+		}
+		stats.Lines++
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		if opts.Trim {
+			line = strings.TrimSpace(line)
+		}
+		if line == "" || opts.Comment != "" && strings.HasPrefix(line, opts.Comment) {
+			stats.Skipped++
+			continue
+		}
+		if err := add(line, stats.Lines); err != nil {
+			return stats, err
+		}
+	}
+}
+
+// WriteLines writes the elements of s to w, one per line.
+// The elements are sorted if the underlying type of T is ordered.
+func WriteLines[T any](w io.Writer, s ReadOnlySet[T]) error {
+	bw := bufio.NewWriter(w)
+	for _, elem := range sortIfOrdered(s.Elements()) {
+		str := FormatElement(elem)
+		if strings.ContainsAny(str, "\r\n") {
+			return fmt.Errorf("set: element %q contains a line break", str)
+		}
+		bw.WriteString(str)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// ReadCSV reads CSV records from r and adds an element for the field
+// with index column of each record to dst (see [ReadLines]). Records whose
+// field is empty are skipped. If opts.Comment is not empty, it must be a single
+// character and is used as [csv.Reader] Comment; comment lines are not counted
+// as records. Records may have different numbers of fields, but each record
+// that is not skipped must have the column. Errors in the CSV format are
+// returned as [*csv.ParseError].
+func ReadCSV[T any](r io.Reader, column int, dst Set[T], parse func(string) (T, error), opts *LineOptions) (ReadStats, error) {
+	if opts == nil {
+		opts = &LineOptions{}
+	}
+	var stats ReadStats
+	add := lineAdder(dst, parse, &stats)
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	if opts.Comment != "" {
+		c, n := utf8.DecodeRuneInString(opts.Comment)
+		if n != len(opts.Comment) {
+			return stats, fmt.Errorf("set: CSV comment %q is not a single character", opts.Comment)
+		}
+		cr.Comment = c
+	}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return stats, nil
+		}
+		if err != nil {
+			return stats, err
+		}
+		stats.Lines++
+		line, _ := cr.FieldPos(0)
+		if stats.Lines == 1 && opts.Header {
+			stats.Skipped++
+			continue
+		}
+		if column >= len(record) {
+			return stats, &LineError{Line: line, Err: fmt.Errorf("missing column %d", column)}
+		}
+		field := record[column]
+		if opts.Trim {
+			field = strings.TrimSpace(field)
+		}
+		if field == "" {
+			stats.Skipped++
+			continue
+		}
+		line, _ = cr.FieldPos(column)
+		if err := add(field, line); err != nil {
+			return stats, err
+		}
+	}
+}
+
+// WriteCSV writes the elements of s to w as CSV records with one field.
+// If header is not empty, it is written as first record.
+// The elements are sorted if the underlying type of T is ordered.
+func WriteCSV[T any](w io.Writer, header string, s ReadOnlySet[T]) error {
+	cw := csv.NewWriter(w)
+	if header != "" {
+		if err := cw.Write([]string{header}); err != nil {
+			return err
+		}
+	}
+	record := make([]string, 1)
+	for _, elem := range sortIfOrdered(s.Elements()) {
+		record[0] = FormatElement(elem)
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
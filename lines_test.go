@@ -0,0 +1,156 @@
+package set
+
+import (
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadLines(t *testing.T) {
+	var tests = []struct {
+		input string
+		opts  *LineOptions
+		want  []string
+		stats ReadStats
+	}{
+		{"", nil, []string{}, ReadStats{}},
+		{"a\nb\na\n", nil, []string{"a", "b"}, ReadStats{3, 2, 1, 0}},
+		{"a\r\n\n b \n# c\nd", nil, []string{" b ", "# c", "a", "d"}, ReadStats{5, 4, 0, 1}},
+		{"a\r\n\n b \n# c\nd", &LineOptions{Comment: "#", Trim: true}, []string{"a", "b", "d"}, ReadStats{5, 3, 0, 2}},
+		{"  \n  // x\n", &LineOptions{Comment: "//", Trim: true}, []string{}, ReadStats{2, 0, 0, 2}},
+	}
+	for i, test := range tests {
+		s := NewTreeSet[string]()
+		stats, err := ReadLines(strings.NewReader(test.input), s, nil, test.opts)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if got := s.Elements(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+		if stats != test.stats {
+			t.Errorf("%d: got %+v, want %+v", i, stats, test.stats)
+		}
+	}
+}
+
+func TestReadLinesError(t *testing.T) {
+	s := NewMapSet[int]()
+	stats, err := ReadLines(strings.NewReader("1\n2\n\nx\n3\n"), s, strconv.Atoi, nil)
+	var lerr *LineError
+	if !errors.As(err, &lerr) || lerr.Line != 4 {
+		t.Fatalf("got %v, want error in line 4", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("got %v, want %v", err, strconv.ErrSyntax)
+	}
+	if want := (ReadStats{4, 2, 0, 1}); stats != want {
+		t.Errorf("got %+v, want %+v", stats, want)
+	}
+}
+
+func TestWriteLines(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteLines[int](&sb, NewMapSet(3, 1, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sb.String(), "1\n2\n3\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	s := NewMapSet[int]()
+	if _, err := ReadLines(strings.NewReader(sb.String()), s, nil, nil); err != nil || !s.Equal(NewMapSet(1, 2, 3)) {
+		t.Errorf("got %v and %v, want nil and {1, 2, 3}", err, s)
+	}
+	sb.Reset()
+	durs := NewTreeSet(time.Second, time.Minute)
+	if err := WriteLines[time.Duration](&sb, durs); err != nil {
+		t.Fatal(err)
+	}
+	durs2 := NewTreeSet[time.Duration]()
+	if _, err := ReadLines(strings.NewReader(sb.String()), durs2, nil, nil); err != nil || !durs2.Equal(durs) {
+		t.Errorf("got %v and %v, want nil and %v", err, durs2, durs)
+	}
+	if err := WriteLines[string](&sb, NewMapSet("a\nb")); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	input := "name,id\n# note: \"quoted\nalice,1\nbob,\n\"carol\nx\",3\nalice,4\n,5\n"
+	var tests = []struct {
+		column int
+		opts   *LineOptions
+		want   []string
+		stats  ReadStats
+	}{
+		{0, &LineOptions{Comment: "#", Header: true}, []string{"alice", "bob", "carol\nx"}, ReadStats{6, 3, 1, 2}},
+		{1, &LineOptions{Comment: "#", Header: true}, []string{"1", "3", "4", "5"}, ReadStats{6, 4, 0, 2}},
+		{0, &LineOptions{Comment: "#"}, []string{"alice", "bob", "carol\nx", "name"}, ReadStats{6, 4, 1, 1}},
+	}
+	for i, test := range tests {
+		s := NewTreeSet[string]()
+		stats, err := ReadCSV(strings.NewReader(input), test.column, s, nil, test.opts)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if got := s.Elements(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %q, want %q", i, got, test.want)
+		}
+		if stats != test.stats {
+			t.Errorf("%d: got %+v, want %+v", i, stats, test.stats)
+		}
+	}
+}
+
+func TestReadCSVError(t *testing.T) {
+	var tests = []struct {
+		input string
+		line  int
+	}{
+		{"1,2\n3\n", 2},
+		{"1,2\n\"x\ny\",z\n", 3},
+	}
+	for i, test := range tests {
+		_, err := ReadCSV(strings.NewReader(test.input), 1, NewMapSet[int](), strconv.Atoi, nil)
+		var lerr *LineError
+		if !errors.As(err, &lerr) || lerr.Line != test.line {
+			t.Errorf("%d: got %v, want error in line %d", i, err, test.line)
+		}
+	}
+	_, err := ReadCSV(strings.NewReader("a,\"b\n"), 0, NewMapSet[string](), nil, nil)
+	var perr *csv.ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("got %v, want csv.ParseError", err)
+	}
+	if _, err := ReadCSV(strings.NewReader("a\n"), 0, NewMapSet[string](), nil, &LineOptions{Comment: "//"}); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteCSV[string](&sb, "name", NewMapSet("b", "a,c", "x\"y")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sb.String(), "name\n\"a,c\"\nb\n\"x\"\"y\"\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	s := NewMapSet[string]()
+	if _, err := ReadCSV(strings.NewReader(sb.String()), 0, s, nil, &LineOptions{Header: true}); err != nil ||
+		!s.Equal(NewMapSet("b", "a,c", "x\"y")) {
+		t.Errorf("got %v and %v, want nil and same set", err, s)
+	}
+	sb.Reset()
+	durs := NewTreeSet(time.Second, time.Minute)
+	if err := WriteCSV[time.Duration](&sb, "", durs); err != nil {
+		t.Fatal(err)
+	}
+	durs2 := NewTreeSet[time.Duration]()
+	if _, err := ReadCSV(strings.NewReader(sb.String()), 0, durs2, nil, nil); err != nil || !durs2.Equal(durs) {
+		t.Errorf("got %v and %v, want nil and %v", err, durs2, durs)
+	}
+}
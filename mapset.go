@@ -1,13 +1,17 @@
 package set
 
 import (
-	"fmt"
 	"iter"
-	"strings"
+	"slices"
 )
 
 // MapSet type that implements the [Set] interface.
 // It uses a Go map to store the elements.
+//
+// The zero value is an empty set ready to use. A nil *MapSet behaves like
+// an empty set for all methods that do not add elements, except for the
+// methods with value receivers (MarshalJSON, MarshalText, MarshalBinary and
+// GobEncode), which panic.
 type MapSet[T comparable] struct {
 	data map[T]struct{}
 }
@@ -21,15 +25,53 @@ func NewMapSet[T comparable](elems ...T) *MapSet[T] {
 	return &MapSet[T]{data: data}
 }
 
+// m returns the map of the Set; it is nil for a nil MapSet.
+func (s *MapSet[T]) m() map[T]struct{} {
+	if s == nil {
+		return nil
+	}
+	return s.data
+}
+
+// init allocates the map of a zero MapSet.
+func (s *MapSet[T]) init() {
+	if s.data == nil {
+		s.data = make(map[T]struct{})
+	}
+}
+
 // Contains reports whether the element is in the Set.
 func (s *MapSet[T]) Contains(elem T) bool {
-	_, ok := s.data[elem]
+	_, ok := s.m()[elem]
 	return ok
 }
 
+// Get returns the element of the Set that is equal to probe.
+// Returns false if there is no such element. Because equal elements
+// of a MapSet are compared with ==, the result is probe itself.
+func (s *MapSet[T]) Get(probe T) (T, bool) {
+	if _, ok := s.m()[probe]; ok {
+		return probe, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Replace adds elem to the Set, replacing an equal element.
+// Returns the replaced element and true, or false if elem was added.
+func (s *MapSet[T]) Replace(elem T) (T, bool) {
+	old, ok := s.Get(elem)
+	if ok {
+		delete(s.data, elem)
+	}
+	s.Add(elem)
+	return old, ok
+}
+
 // Add adds an element to the Set.
 // Returns true if it was added, false if it already was in the set.
 func (s *MapSet[T]) Add(elem T) bool {
+	s.init()
 	if _, ok := s.data[elem]; !ok {
 		s.data[elem] = struct{}{}
 		return true
@@ -44,34 +86,87 @@ func (s *MapSet[T]) Update(elems ...T) {
 	}
 }
 
+// UpdateSeq updates the Set with the elements of seq.
+func (s *MapSet[T]) UpdateSeq(seq iter.Seq[T]) {
+	for elem := range seq {
+		s.Add(elem)
+	}
+}
+
 // Remove removes an element from the Set.
 // Returns true if it was in the set, false otherwise.
 func (s *MapSet[T]) Remove(elem T) bool {
-	if _, ok := s.data[elem]; ok {
-		delete(s.data, elem)
+	if _, ok := s.m()[elem]; ok {
+		delete(s.m(), elem)
 		return true
 	}
 	return false
 }
 
+// Clear removes all elements from the Set. The allocated memory is kept.
+func (s *MapSet[T]) Clear() {
+	clear(s.m())
+}
+
+// Pop removes and returns an arbitrary element of the Set.
+// Returns false if the Set is empty.
+func (s *MapSet[T]) Pop() (T, bool) {
+	for elem := range s.m() {
+		delete(s.data, elem)
+		return elem, true
+	}
+	var zero T
+	return zero, false
+}
+
+// RemoveIf removes all elements from the Set for which pred returns true.
+// Returns the number of elements that were removed.
+func (s *MapSet[T]) RemoveIf(pred func(T) bool) int {
+	n := len(s.m())
+	for elem := range s.m() {
+		if pred(elem) {
+			delete(s.m(), elem)
+		}
+	}
+	return n - len(s.m())
+}
+
+// RetainIf removes all elements from the Set for which pred returns false.
+// Returns the number of elements that were removed.
+func (s *MapSet[T]) RetainIf(pred func(T) bool) int {
+	return s.RemoveIf(func(elem T) bool {
+		return !pred(elem)
+	})
+}
+
 // IsEmpty returns true if Set is an empty set.
 func (s *MapSet[T]) IsEmpty() bool {
-	return len(s.data) == 0
+	return len(s.m()) == 0
 }
 
 // Cardinality returns the number of elements in the Set.
 func (s *MapSet[T]) Cardinality() int {
-	return len(s.data)
+	return len(s.m())
+}
+
+// Any returns an arbitrary element of the Set without removing it.
+// Returns false if the Set is empty.
+func (s *MapSet[T]) Any() (T, bool) {
+	for elem := range s.m() {
+		return elem, true
+	}
+	var zero T
+	return zero, false
 }
 
 // Union returns a new Set which is the union of s and s2.
-func (s *MapSet[T]) Union(s2 Set[T]) Set[T] {
+func (s *MapSet[T]) Union(s2 ReadOnlySet[T]) Set[T] {
 	m := make(map[T]struct{})
-	for elem := range s.data {
+	for elem := range s.m() {
 		m[elem] = struct{}{}
 	}
-	if x, ok := s2.(*MapSet[T]); ok {
-		for elem := range x.data {
+	if x, ok := unwrap(s2).(*MapSet[T]); ok {
+		for elem := range x.m() {
 			m[elem] = struct{}{}
 		}
 	} else {
@@ -83,9 +178,9 @@ func (s *MapSet[T]) Union(s2 Set[T]) Set[T] {
 }
 
 // Intersection returns a new Set which is the intersection of s and s2.
-func (s *MapSet[T]) Intersection(s2 Set[T]) Set[T] {
+func (s *MapSet[T]) Intersection(s2 ReadOnlySet[T]) Set[T] {
 	m := make(map[T]struct{})
-	for elem := range s.data {
+	for elem := range s.m() {
 		if s2.Contains(elem) {
 			m[elem] = struct{}{}
 		}
@@ -94,9 +189,9 @@ func (s *MapSet[T]) Intersection(s2 Set[T]) Set[T] {
 }
 
 // Difference returns a new Set which is the set difference of s and s2.
-func (s *MapSet[T]) Difference(s2 Set[T]) Set[T] {
+func (s *MapSet[T]) Difference(s2 ReadOnlySet[T]) Set[T] {
 	m := make(map[T]struct{})
-	for elem := range s.data {
+	for elem := range s.m() {
 		if !s2.Contains(elem) {
 			m[elem] = struct{}{}
 		}
@@ -105,22 +200,22 @@ func (s *MapSet[T]) Difference(s2 Set[T]) Set[T] {
 }
 
 // SymDifference returns a new Set which is the symmetric difference of s and s2.
-func (s *MapSet[T]) SymDifference(s2 Set[T]) Set[T] {
+func (s *MapSet[T]) SymDifference(s2 ReadOnlySet[T]) Set[T] {
 	m := make(map[T]struct{})
-	for elem := range s.data {
+	for elem := range s.m() {
 		if !s2.Contains(elem) {
 			m[elem] = struct{}{}
 		}
 	}
-	if x, ok := s2.(*MapSet[T]); ok {
-		for elem := range x.data {
-			if _, ok := s.data[elem]; !ok {
+	if x, ok := unwrap(s2).(*MapSet[T]); ok {
+		for elem := range x.m() {
+			if _, ok := s.m()[elem]; !ok {
 				m[elem] = struct{}{}
 			}
 		}
 	} else {
 		for _, elem := range s2.Elements() {
-			if _, ok := s.data[elem]; !ok {
+			if _, ok := s.m()[elem]; !ok {
 				m[elem] = struct{}{}
 			}
 		}
@@ -128,12 +223,107 @@ func (s *MapSet[T]) SymDifference(s2 Set[T]) Set[T] {
 	return &MapSet[T]{data: m}
 }
 
+// UnionWith adds all elements of s2 to s.
+// Returns the number of elements that were added.
+func (s *MapSet[T]) UnionWith(s2 ReadOnlySet[T]) int {
+	s.init()
+	n := len(s.data)
+	if x, ok := unwrap(s2).(*MapSet[T]); ok {
+		for elem := range x.m() {
+			s.data[elem] = struct{}{}
+		}
+	} else {
+		for elem := range s2.Iter() {
+			s.data[elem] = struct{}{}
+		}
+	}
+	return len(s.data) - n
+}
+
+// IntersectWith removes all elements from s that are not in s2.
+// Returns the number of elements that were removed.
+func (s *MapSet[T]) IntersectWith(s2 ReadOnlySet[T]) int {
+	n := len(s.m())
+	for elem := range s.m() {
+		if !s2.Contains(elem) {
+			delete(s.m(), elem)
+		}
+	}
+	return n - len(s.m())
+}
+
+// DifferenceWith removes all elements of s2 from s.
+// Returns the number of elements that were removed.
+func (s *MapSet[T]) DifferenceWith(s2 ReadOnlySet[T]) int {
+	n := len(s.m())
+	if unwrap(s2) == ReadOnlySet[T](s) {
+		clear(s.m())
+	} else if s2.Cardinality() < n {
+		for elem := range s2.Iter() {
+			delete(s.m(), elem)
+		}
+	} else {
+		for elem := range s.m() {
+			if s2.Contains(elem) {
+				delete(s.m(), elem)
+			}
+		}
+	}
+	return n - len(s.m())
+}
+
+// SymDifferenceWith removes all elements of s2 from s that are in s
+// and adds all others. Returns the number of elements that were added or removed.
+func (s *MapSet[T]) SymDifferenceWith(s2 ReadOnlySet[T]) int {
+	s.init()
+	if unwrap(s2) == ReadOnlySet[T](s) {
+		n := len(s.data)
+		clear(s.data)
+		return n
+	}
+	n := 0
+	for elem := range s2.Iter() {
+		if _, ok := s.data[elem]; ok {
+			delete(s.data, elem)
+		} else {
+			s.data[elem] = struct{}{}
+		}
+		n++
+	}
+	return n
+}
+
+// IntersectionCardinality returns the cardinality of the intersection of s and s2.
+func (s *MapSet[T]) IntersectionCardinality(s2 ReadOnlySet[T]) int {
+	return countCommon[T](s, s2, 0)
+}
+
+// UnionCardinality returns the cardinality of the union of s and s2.
+func (s *MapSet[T]) UnionCardinality(s2 ReadOnlySet[T]) int {
+	return len(s.m()) + s2.Cardinality() - countCommon[T](s, s2, 0)
+}
+
+// DifferenceCardinality returns the cardinality of the set difference of s and s2.
+func (s *MapSet[T]) DifferenceCardinality(s2 ReadOnlySet[T]) int {
+	return len(s.m()) - countCommon[T](s, s2, 0)
+}
+
+// IsDisjoint returns true if s and s2 have no elements in common.
+func (s *MapSet[T]) IsDisjoint(s2 ReadOnlySet[T]) bool {
+	return countCommon[T](s, s2, 1) == 0
+}
+
+// Overlaps returns true if s and s2 have at least one element in common.
+func (s *MapSet[T]) Overlaps(s2 ReadOnlySet[T]) bool {
+	return countCommon[T](s, s2, 1) > 0
+}
+
 // IsSubset returns true if s is a subset of s2.
-func (s *MapSet[T]) IsSubset(s2 Set[T]) bool {
-	if len(s.data) > s2.Cardinality() {
+func (s *MapSet[T]) IsSubset(s2 ReadOnlySet[T]) bool {
+	if len(s.m()) > s2.Cardinality() {
 		return false
 	}
-	for elem := range s.data {
+	for elem := range s.m() {
 		if !s2.Contains(elem) {
 			return false
 		}
@@ -142,16 +332,37 @@ func (s *MapSet[T]) IsSubset(s2 Set[T]) bool {
 }
 
 // IsProperSubset returns true if s is a proper subset of s2.
-func (s *MapSet[T]) IsProperSubset(s2 Set[T]) bool {
-	if len(s.data) >= s2.Cardinality() {
+func (s *MapSet[T]) IsProperSubset(s2 ReadOnlySet[T]) bool {
+	if len(s.m()) >= s2.Cardinality() {
 		return false
 	}
 	return s.IsSubset(s2)
 }
 
+// IsSuperset returns true if s is a superset of s2.
+func (s *MapSet[T]) IsSuperset(s2 ReadOnlySet[T]) bool {
+	if len(s.m()) < s2.Cardinality() {
+		return false
+	}
+	for elem := range s2.Iter() {
+		if _, ok := s.m()[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsProperSuperset returns true if s is a proper superset of s2.
+func (s *MapSet[T]) IsProperSuperset(s2 ReadOnlySet[T]) bool {
+	if len(s.m()) <= s2.Cardinality() {
+		return false
+	}
+	return s.IsSuperset(s2)
+}
+
 // Equal returns true if s and s2 contain the same elements.
-func (s *MapSet[T]) Equal(s2 Set[T]) bool {
-	if len(s.data) != s2.Cardinality() {
+func (s *MapSet[T]) Equal(s2 ReadOnlySet[T]) bool {
+	if len(s.m()) != s2.Cardinality() {
 		return false
 	}
 	return s.IsSubset(s2)
@@ -159,8 +370,13 @@ func (s *MapSet[T]) Equal(s2 Set[T]) bool {
 
 // Clone clones the Set.
 func (s *MapSet[T]) Clone() Set[T] {
-	m := make(map[T]struct{}, len(s.data))
-	for elem := range s.data {
+	return s.cloneCap(len(s.m()))
+}
+
+// cloneCap clones the Set with space for at least n elements.
+func (s *MapSet[T]) cloneCap(n int) Set[T] {
+	m := make(map[T]struct{}, max(n, len(s.m())))
+	for elem := range s.m() {
 		m[elem] = struct{}{}
 	}
 	return &MapSet[T]{data: m}
@@ -168,8 +384,8 @@ func (s *MapSet[T]) Clone() Set[T] {
 
 // Elements returns a slice with all elements of the Set.
 func (s *MapSet[T]) Elements() []T {
-	result := make([]T, 0, len(s.data))
-	for elem := range s.data {
+	result := make([]T, 0, len(s.m()))
+	for elem := range s.m() {
 		result = append(result, elem)
 	}
 	return result
@@ -178,7 +394,7 @@ func (s *MapSet[T]) Elements() []T {
 // Iter returns an iterator over all elements of the Set.
 func (s *MapSet[T]) Iter() iter.Seq[T] {
 	return func(yield func(T) bool) {
-		for elem := range s.data {
+		for elem := range s.m() {
 			if !yield(elem) {
 				return
 			}
@@ -186,11 +402,41 @@ func (s *MapSet[T]) Iter() iter.Seq[T] {
 	}
 }
 
-// String returns a string representation of the Set.
-func (s *MapSet[T]) String() string {
-	sl := make([]string, 0, len(s.data))
-	for elem := range s.data {
-		sl = append(sl, fmt.Sprintf("%v", elem))
+// SortedElements returns a slice with all elements of the Set sorted by cmp.
+// If cmp is nil, [cmp.Compare] is used; it panics if the underlying type
+// of T is not ordered.
+func (s *MapSet[T]) SortedElements(cmp func(T, T) int) []T {
+	if cmp == nil {
+		var ok bool
+		if cmp, ok = orderedCmp[T](); !ok {
+			panic(errUnordered)
+		}
 	}
-	return fmt.Sprintf("MapSet{%s}", strings.Join(sl, ", "))
+	elems := s.Elements()
+	slices.SortFunc(elems, cmp)
+	return elems
+}
+
+// IterSorted returns an iterator over all elements of the Set sorted by cmp
+// (see [MapSet.SortedElements]). The elements are sorted when the iteration starts.
+func (s *MapSet[T]) IterSorted(cmp func(T, T) int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, elem := range s.SortedElements(cmp) {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// String returns a string representation of the Set (see [ParseMapSet]).
+// The elements are in map order (see [MapSet.SortedString]).
+func (s *MapSet[T]) String() string {
+	return formatSet("MapSet", len(s.m()), s.Iter())
+}
+
+// SortedString returns the same as [MapSet.String], but the elements are
+// sorted if the underlying type of T is ordered.
+func (s *MapSet[T]) SortedString() string {
+	return formatSet("MapSet", len(s.m()), slices.Values(sortIfOrdered(s.Elements())))
 }
@@ -311,3 +311,229 @@ func TestString(t *testing.T) {
 		t.Errorf("got %q, want %q or %q", got, want1, want2)
 	}
 }
+
+func TestInPlace(t *testing.T) {
+	var tests = []struct {
+		f    func(s *MapSet[int], s2 ReadOnlySet[int]) int
+		n    int
+		want map[int]struct{}
+	}{
+		{(*MapSet[int]).UnionWith, 1, map[int]struct{}{1: {}, 2: {}, 3: {}}},
+		{(*MapSet[int]).IntersectWith, 1, map[int]struct{}{2: {}}},
+		{(*MapSet[int]).DifferenceWith, 1, map[int]struct{}{1: {}}},
+		{(*MapSet[int]).SymDifferenceWith, 2, map[int]struct{}{1: {}, 3: {}}},
+	}
+	for i, test := range tests {
+		for j, s2 := range []ReadOnlySet[int]{NewMapSet(2, 3), NewTreeSet(2, 3)} {
+			s := NewMapSet(1, 2)
+			if n := test.f(s, s2); n != test.n {
+				t.Errorf("%d/%d: got %d, want %d", i, j, n, test.n)
+			}
+			if !reflect.DeepEqual(s.data, test.want) {
+				t.Errorf("%d/%d: got %v, want %v", i, j, s.data, test.want)
+			}
+		}
+	}
+}
+
+func TestInPlaceSelf(t *testing.T) {
+	s := NewMapSet(1, 2)
+	if n := s.SymDifferenceWith(s); n != 2 || !s.IsEmpty() {
+		t.Errorf("got %d and %v, want 2 and empty set", n, s)
+	}
+	s = NewMapSet(1, 2)
+	if n := s.DifferenceWith(ReadOnly[int](s)); n != 2 || !s.IsEmpty() {
+		t.Errorf("got %d and %v, want 2 and empty set", n, s)
+	}
+}
+
+func TestCardinalities(t *testing.T) {
+	var tests = []struct {
+		s1                 *MapSet[int]
+		s2                 ReadOnlySet[int]
+		inter, union, diff int
+		disjoint           bool
+	}{
+		{NewMapSet[int](), NewMapSet[int](), 0, 0, 0, true},
+		{NewMapSet(1, 2), NewMapSet[int](), 0, 2, 2, true},
+		{NewMapSet(1, 2), NewMapSet(3), 0, 3, 2, true},
+		{NewMapSet(1, 2), NewTreeSet(2, 3, 4), 1, 4, 1, false},
+		{NewMapSet(1, 2), NewTreeSet(1, 2), 2, 2, 0, false},
+	}
+	for i, test := range tests {
+		if n := test.s1.IntersectionCardinality(test.s2); n != test.inter {
+			t.Errorf("%d: got intersection %d, want %d", i, n, test.inter)
+		}
+		if n := test.s1.UnionCardinality(test.s2); n != test.union {
+			t.Errorf("%d: got union %d, want %d", i, n, test.union)
+		}
+		if n := test.s1.DifferenceCardinality(test.s2); n != test.diff {
+			t.Errorf("%d: got difference %d, want %d", i, n, test.diff)
+		}
+		if b := test.s1.IsDisjoint(test.s2); b != test.disjoint {
+			t.Errorf("%d: got disjoint %t, want %t", i, b, test.disjoint)
+		}
+		if b := test.s1.Overlaps(test.s2); b == test.disjoint {
+			t.Errorf("%d: got overlaps %t, want %t", i, b, !test.disjoint)
+		}
+	}
+}
+
+func TestIsSuperset(t *testing.T) {
+	var tests = []struct {
+		s1     *MapSet[int]
+		s2     ReadOnlySet[int]
+		super  bool
+		proper bool
+	}{
+		{NewMapSet[int](), NewMapSet[int](), true, false},
+		{NewMapSet(1), NewMapSet[int](), true, true},
+		{NewMapSet(1, 2), NewTreeSet(1), true, true},
+		{NewMapSet(1, 2), NewTreeSet(1, 2), true, false},
+		{NewMapSet(1), NewMapSet(1, 2), false, false},
+		{NewMapSet(1, 3, 4), NewMapSet(1, 2), false, false},
+	}
+	for i, test := range tests {
+		if got := test.s1.IsSuperset(test.s2); got != test.super {
+			t.Errorf("%d: got %t, want %t", i, got, test.super)
+		}
+		if got := test.s1.IsProperSuperset(test.s2); got != test.proper {
+			t.Errorf("%d: got %t, want %t", i, got, test.proper)
+		}
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	var s MapSet[string]
+	if !s.IsEmpty() || s.Contains("a") {
+		t.Error("zero value is not empty")
+	}
+	if !s.Add("a") {
+		t.Error("got false, want true")
+	}
+	s.UnionWith(NewMapSet("b"))
+	if want := map[string]struct{}{"a": {}, "b": {}}; !reflect.DeepEqual(s.data, want) {
+		t.Errorf("got %v, want %v", s.data, want)
+	}
+	var s2 MapSet[string]
+	s2.SymDifferenceWith(NewMapSet("c"))
+	if !s2.Contains("c") {
+		t.Error("got false, want true")
+	}
+}
+
+func TestNilReceiver(t *testing.T) {
+	var s *MapSet[int]
+	if !s.IsEmpty() || s.Contains(1) || s.Cardinality() != 0 || s.Remove(1) {
+		t.Error("nil set is not empty")
+	}
+	if got := s.Elements(); len(got) != 0 {
+		t.Errorf("got %v, want empty slice", got)
+	}
+	if got := s.String(); got != "MapSet{}" {
+		t.Errorf("got %q, want %q", got, "MapSet{}")
+	}
+	if !s.IsSubset(NewMapSet(1)) || s.Overlaps(NewMapSet(1)) {
+		t.Error("nil set is not empty")
+	}
+	if got := s.Union(NewMapSet(1)); !got.Equal(NewMapSet(1)) {
+		t.Errorf("got %v, want {1}", got)
+	}
+	if !NewMapSet[int]().Equal(s) || !NewMapSet(1).Union(s).Equal(NewMapSet(1)) {
+		t.Error("nil set is not empty")
+	}
+	c := s.Clone()
+	if !c.Add(1) {
+		t.Error("got false, want true")
+	}
+}
+
+func TestClearPopAny(t *testing.T) {
+	s := NewMapSet(1, 2)
+	elem, ok := s.Any()
+	if !ok || !s.Contains(elem) {
+		t.Errorf("got %d and %t", elem, ok)
+	}
+	seen := map[int]struct{}{}
+	for {
+		elem, ok := s.Pop()
+		if !ok {
+			break
+		}
+		seen[elem] = struct{}{}
+	}
+	if want := map[int]struct{}{1: {}, 2: {}}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("got %v, want %v", seen, want)
+	}
+	if _, ok := s.Any(); ok {
+		t.Error("got true, want false")
+	}
+	s.Update(1, 2, 3)
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Errorf("got %v, want empty set", s)
+	}
+	var s2 *MapSet[int]
+	if _, ok := s2.Pop(); ok {
+		t.Error("got true, want false")
+	}
+	s2.Clear()
+}
+
+func TestGetReplace(t *testing.T) {
+	s := NewMapSet(1, 2)
+	if elem, ok := s.Get(2); elem != 2 || !ok {
+		t.Errorf("got %d and %t, want 2 and true", elem, ok)
+	}
+	if elem, ok := s.Get(3); elem != 0 || ok {
+		t.Errorf("got %d and %t, want 0 and false", elem, ok)
+	}
+	if old, ok := s.Replace(2); old != 2 || !ok {
+		t.Errorf("got %d and %t, want 2 and true", old, ok)
+	}
+	if _, ok := s.Replace(3); ok {
+		t.Error("got true, want false")
+	}
+	if want := map[int]struct{}{1: {}, 2: {}, 3: {}}; !reflect.DeepEqual(s.data, want) {
+		t.Errorf("got %v, want %v", s.data, want)
+	}
+}
+
+func TestSortedElements(t *testing.T) {
+	s := NewMapSet(3, 1, 2)
+	if got, want := s.SortedElements(nil), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	desc := func(a, b int) int { return b - a }
+	if got, want := s.SortedElements(desc), []int{3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	var got []int
+	for elem := range s.IterSorted(desc) {
+		if elem == 1 {
+			break
+		}
+		got = append(got, elem)
+	}
+	if want := []int{3, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := (*MapSet[int])(nil).SortedElements(nil); len(got) != 0 {
+		t.Errorf("got %v, want []", got)
+	}
+	defer func() {
+		if r := recover(); r != errUnordered {
+			t.Errorf("got %v, want %v", r, errUnordered)
+		}
+	}()
+	NewMapSet(struct{}{}).SortedElements(nil)
+}
+
+func TestSortedString(t *testing.T) {
+	s := NewMapSet(5, 3, 1, 4, 2)
+	for range 10 {
+		if got, want := s.SortedString(), "MapSet{1, 2, 3, 4, 5}"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// scalar is a decoded integer, floating-point, string or bool value.
+type scalar struct {
+	kind reflect.Kind // reflect.Int, reflect.Uint, reflect.Float64, reflect.String or reflect.Bool
+	i    int64
+	u    uint64
+	f    float64
+	s    string
+	b    bool
+}
+
+// set stores x in rv. Returns false if x does not fit the type of rv.
+func (x scalar) set(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := x.i
+		if x.kind == reflect.Uint {
+			if x.u > math.MaxInt64 {
+				return false
+			}
+			i = int64(x.u)
+		} else if x.kind != reflect.Int {
+			return false
+		}
+		if rv.OverflowInt(i) {
+			return false
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := x.u
+		if x.kind == reflect.Int {
+			if x.i < 0 {
+				return false
+			}
+			u = uint64(x.i)
+		} else if x.kind != reflect.Uint {
+			return false
+		}
+		if rv.OverflowUint(u) {
+			return false
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		if x.kind != reflect.Float64 || rv.OverflowFloat(x.f) {
+			return false
+		}
+		rv.SetFloat(x.f)
+	case reflect.String:
+		if x.kind != reflect.String {
+			return false
+		}
+		rv.SetString(x.s)
+	case reflect.Bool:
+		if x.kind != reflect.Bool {
+			return false
+		}
+		rv.SetBool(x.b)
+	default:
+		return false
+	}
+	return true
+}
+
+// decodeScalar decodes the beginning of b with decode and stores the value in an element.
+func decodeScalar[T any](b []byte, decode func([]byte) (scalar, int, error)) (T, int, error) {
+	var elem T
+	x, n, err := decode(b)
+	if err != nil {
+		return elem, 0, err
+	}
+	if !x.set(reflect.ValueOf(&elem).Elem()) {
+		return elem, 0, fmt.Errorf("%w: cannot decode %s into %T", ErrInvalidBinary, x.kind, elem)
+	}
+	return elem, n, nil
+}
+
+// readUint reads a big-endian unsigned integer with size bytes from b.
+func readUint(b []byte, size int) (uint64, bool) {
+	if len(b) < size {
+		return 0, false
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), true
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), true
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), true
+	}
+	return binary.BigEndian.Uint64(b), true
+}
+
+// readString returns a string scalar with l bytes that starts at offset off in b
+// and the offset after it.
+func readString(b []byte, off int, l uint64) (scalar, int, error) {
+	if off > len(b) || l > uint64(len(b)-off) {
+		return scalar{}, 0, errTruncated
+	}
+	end := off + int(l)
+	return scalar{kind: reflect.String, s: string(b[off:end])}, end, nil
+}
+
+var errTruncated = fmt.Errorf("%w: unexpected end of data", ErrInvalidBinary)
+
+// msgpackCodec is the builtin Codec for MessagePack. It supports elements whose
+// underlying type is an integer, floating-point, string or bool type.
+type msgpackCodec[T any] struct{}
+
+func (msgpackCodec[T]) AppendElement(b []byte, elem T) ([]byte, error) {
+	rv := reflect.ValueOf(elem)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := rv.Int()
+		switch {
+		case v >= 0:
+			return appendMsgpackUint(b, uint64(v)), nil
+		case v >= -32:
+			return append(b, byte(v)), nil
+		case v >= math.MinInt8:
+			return append(b, 0xd0, byte(v)), nil
+		case v >= math.MinInt16:
+			return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(v)), nil
+		case v >= math.MinInt32:
+			return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(v)), nil
+		}
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(v)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendMsgpackUint(b, rv.Uint()), nil
+	case reflect.Float32:
+		return binary.BigEndian.AppendUint32(append(b, 0xca), math.Float32bits(float32(rv.Float()))), nil
+	case reflect.Float64:
+		return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(rv.Float())), nil
+	case reflect.String:
+		str := rv.String()
+		switch l := len(str); {
+		case l < 32:
+			b = append(b, 0xa0|byte(l))
+		case l <= math.MaxUint8:
+			b = append(b, 0xd9, byte(l))
+		case l <= math.MaxUint16:
+			b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(l))
+		default:
+			b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(l))
+		}
+		return append(b, str...), nil
+	case reflect.Bool:
+		if rv.Bool() {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	}
+	return nil, fmt.Errorf("set: no MessagePack encoding for %T", elem)
+}
+
+func appendMsgpackUint(b []byte, v uint64) []byte {
+	switch {
+	case v <= math.MaxInt8:
+		return append(b, byte(v))
+	case v <= math.MaxUint8:
+		return append(b, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(v))
+	case v <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(v))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xcf), v)
+}
+
+func (msgpackCodec[T]) DecodeElement(b []byte) (T, int, error) {
+	return decodeScalar[T](b, decodeMsgpackScalar)
+}
+
+func decodeMsgpackScalar(b []byte) (scalar, int, error) {
+	if len(b) == 0 {
+		return scalar{}, 0, errTruncated
+	}
+	c := b[0]
+	switch {
+	case c <= 0x7f:
+		return scalar{kind: reflect.Uint, u: uint64(c)}, 1, nil
+	case c >= 0xe0:
+		return scalar{kind: reflect.Int, i: int64(int8(c))}, 1, nil
+	case c >= 0xa0 && c <= 0xbf:
+		return readString(b, 1, uint64(c&0x1f))
+	}
+	switch c {
+	case 0xc2, 0xc3:
+		return scalar{kind: reflect.Bool, b: c == 0xc3}, 1, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		size := 1 << (c - 0xcc)
+		u, ok := readUint(b[1:], size)
+		if !ok {
+			return scalar{}, 0, errTruncated
+		}
+		return scalar{kind: reflect.Uint, u: u}, 1 + size, nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		u, ok := readUint(b[1:], size)
+		if !ok {
+			return scalar{}, 0, errTruncated
+		}
+		shift := 64 - 8*size
+		return scalar{kind: reflect.Int, i: int64(u<<shift) >> shift}, 1 + size, nil
+	case 0xca:
+		u, ok := readUint(b[1:], 4)
+		if !ok {
+			return scalar{}, 0, errTruncated
+		}
+		return scalar{kind: reflect.Float64, f: float64(math.Float32frombits(uint32(u)))}, 5, nil
+	case 0xcb:
+		u, ok := readUint(b[1:], 8)
+		if !ok {
+			return scalar{}, 0, errTruncated
+		}
+		return scalar{kind: reflect.Float64, f: math.Float64frombits(u)}, 9, nil
+	case 0xd9, 0xda, 0xdb:
+		size := 1 << (c - 0xd9)
+		l, ok := readUint(b[1:], size)
+		if !ok {
+			return scalar{}, 0, errTruncated
+		}
+		return readString(b, 1+size, l)
+	}
+	return scalar{}, 0, fmt.Errorf("%w: unsupported MessagePack type 0x%02x", ErrInvalidBinary, c)
+}
+
+// AppendMsgpack appends the MessagePack encoding of s as array to b and returns
+// the extended buffer. If c is nil, elements whose underlying type is an integer,
+// floating-point, string or bool type are supported. Otherwise c is used for all
+// elements and must encode each one as a MessagePack value.
+// The elements are sorted if the underlying type of T is ordered.
+func AppendMsgpack[T any](b []byte, s ReadOnlySet[T], c Codec[T]) ([]byte, error) {
+	if c == nil {
+		c = msgpackCodec[T]{}
+	}
+	switch n := s.Cardinality(); {
+	case n < 16:
+		b = append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xdc), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdd), uint32(n))
+	}
+	var err error
+	for _, elem := range sortIfOrdered(s.Elements()) {
+		if b, err = c.AppendElement(b, elem); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// DecodeMsgpack decodes a MessagePack array and adds the elements to s.
+// Codec c must be the same that was used for encoding (see [AppendMsgpack]).
+func DecodeMsgpack[T any](data []byte, s Set[T], c Codec[T]) error {
+	if c == nil {
+		c = msgpackCodec[T]{}
+	}
+	if len(data) == 0 {
+		return errTruncated
+	}
+	var count uint64
+	pos := 1
+	switch h := data[0]; {
+	case h&0xf0 == 0x90:
+		count = uint64(h & 0x0f)
+	case h == 0xdc || h == 0xdd:
+		size := 2 << (h - 0xdc)
+		var ok bool
+		if count, ok = readUint(data[1:], size); !ok {
+			return errTruncated
+		}
+		pos += size
+	default:
+		return fmt.Errorf("%w: not a MessagePack array", ErrInvalidBinary)
+	}
+	if count > uint64(len(data)-pos) {
+		return errTruncated
+	}
+	for range count {
+		elem, n, err := c.DecodeElement(data[pos:])
+		if err != nil {
+			return fmt.Errorf("element at offset %d: %w", pos, err)
+		}
+		pos += n
+		s.Add(elem)
+	}
+	if pos != len(data) {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidBinary, len(data)-pos)
+	}
+	return nil
+}
+
+// MarshalMsgpack returns the MessagePack encoding of the Set (see [AppendMsgpack]).
+func (s *MapSet[T]) MarshalMsgpack() ([]byte, error) {
+	return AppendMsgpack[T](nil, s, nil)
+}
+
+// UnmarshalMsgpack decodes a MessagePack array (see [DecodeMsgpack]).
+// The elements of the Set are replaced by the decoded elements.
+func (s *MapSet[T]) UnmarshalMsgpack(data []byte) error {
+	s.Clear()
+	return DecodeMsgpack[T](data, s, nil)
+}
+
+// MarshalMsgpack returns the MessagePack encoding of the Set (see [AppendMsgpack]).
+func (s *TreeSet[T]) MarshalMsgpack() ([]byte, error) {
+	return AppendMsgpack[T](nil, s, nil)
+}
+
+// UnmarshalMsgpack decodes a MessagePack array (see [DecodeMsgpack]).
+// The elements of the Set are replaced by the decoded elements.
+func (s *TreeSet[T]) UnmarshalMsgpack(data []byte) error {
+	s.Clear()
+	return DecodeMsgpack[T](data, s, nil)
+}
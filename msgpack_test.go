@@ -0,0 +1,139 @@
+package set
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMsgpackEncoding(t *testing.T) {
+	var tests = []struct {
+		s    interface{ MarshalMsgpack() ([]byte, error) }
+		want []byte
+	}{
+		{NewMapSet[int](), []byte{0x90}},
+		{NewMapSet(1, -1, 200, -200), []byte{0x94, 0xd1, 0xff, 0x38, 0xff, 0x01, 0xcc, 0xc8}},
+		{NewTreeSet[int64](math.MinInt64), []byte{0x91, 0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+		{NewTreeSet[uint](70000), []byte{0x91, 0xce, 0, 1, 0x11, 0x70}},
+		{NewMapSet("a", ""), []byte{0x92, 0xa0, 0xa1, 'a'}},
+		{NewMapSet(true), []byte{0x91, 0xc3}},
+		{NewMapSet(1.5), []byte{0x91, 0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{NewMapSet[float32](1.5), []byte{0x91, 0xca, 0x3f, 0xc0, 0, 0}},
+	}
+	for i, test := range tests {
+		got, err := test.s.MarshalMsgpack()
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("%d: got % x, want % x", i, got, test.want)
+		}
+	}
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	var ints []int64
+	for i := range 70000 {
+		ints = append(ints, int64(i)*int64(i)*int64(i)-1<<40)
+	}
+	s := NewMapSet(ints...)
+	data, err := s.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2 := NewTreeSet[int64](1)
+	if err := s2.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	if !s2.Equal(s) {
+		t.Error("sets are not equal")
+	}
+	strs := NewTreeSet("", "a", strings.Repeat("b", 40), strings.Repeat("c", 300), strings.Repeat("d", 70000))
+	data, _ = strs.MarshalMsgpack()
+	strs2 := NewMapSet[string]()
+	if err := strs2.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	if !strs2.Equal(strs) {
+		t.Error("sets are not equal")
+	}
+	// signed encoding into unsigned type and vice versa
+	u := NewMapSet[uint8]()
+	if err := DecodeMsgpack(append([]byte{0x92, 0xd0, 0x05}, 0x7f), u, nil); err != nil || !u.Equal(NewMapSet[uint8](5, 127)) {
+		t.Errorf("got %v and %v, want nil and {5, 127}", err, u)
+	}
+}
+
+type point struct{ x, y int }
+
+// pointCodec encodes a point as array of two integers in MessagePack or CBOR.
+type pointCodec struct {
+	head byte
+	c    Codec[int]
+}
+
+func (pc pointCodec) AppendElement(b []byte, p point) ([]byte, error) {
+	b, _ = pc.c.AppendElement(append(b, pc.head), p.x)
+	return pc.c.AppendElement(b, p.y)
+}
+
+func (pc pointCodec) DecodeElement(b []byte) (point, int, error) {
+	if len(b) == 0 || b[0] != pc.head {
+		return point{}, 0, errors.New("not a point")
+	}
+	x, n1, err := pc.c.DecodeElement(b[1:])
+	if err != nil {
+		return point{}, 0, err
+	}
+	y, n2, err := pc.c.DecodeElement(b[1+n1:])
+	return point{x, y}, 1 + n1 + n2, err
+}
+
+func TestMsgpackCodec(t *testing.T) {
+	c := pointCodec{0x92, msgpackCodec[int]{}}
+	s := NewMapSet(point{1, 2}, point{-3, 4})
+	data, err := AppendMsgpack[point](nil, s, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2 := NewMapSet[point]()
+	if err := DecodeMsgpack[point](data, s2, c); err != nil {
+		t.Fatal(err)
+	}
+	if !s2.Equal(s) {
+		t.Errorf("got %v, want %v", s2, s)
+	}
+	if _, err := AppendMsgpack[point](nil, s, nil); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+func TestMsgpackErrors(t *testing.T) {
+	var tests = [][]byte{
+		{},
+		{0x80},
+		{0x92, 0x01},
+		{0x91, 0x01, 0x02},
+		{0x91, 0xa1, 'a'},
+		{0x91, 0xcc},
+		{0x91, 0xcd, 0x01, 0x00},
+		{0x91, 0xff},
+		{0x91, 0xc0},
+		{0xdc, 0x00},
+	}
+	for i, data := range tests {
+		if err := DecodeMsgpack(data, NewMapSet[uint8](), nil); !errors.Is(err, ErrInvalidBinary) {
+			t.Errorf("%d: got %v, want %v", i, err, ErrInvalidBinary)
+		}
+	}
+	s := NewMapSet[float64]()
+	if err := DecodeMsgpack([]byte{0x91, 0xca, 0x3f, 0xc0, 0, 0}, s, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Elements(), []float64{1.5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
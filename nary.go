@@ -0,0 +1,69 @@
+package set
+
+import "slices"
+
+// UnionAll returns a new Set which is the union of all sets.
+// The result has the implementation of the largest set.
+// Returns nil if no sets are given.
+func UnionAll[T any](sets ...ReadOnlySet[T]) Set[T] {
+	if len(sets) == 0 {
+		return nil
+	}
+	largest, size, total := 0, 0, 0
+	for i, s := range sets {
+		n := s.Cardinality()
+		if n > size {
+			largest, size = i, n
+		}
+		total += n
+	}
+	var result Set[T]
+	if x, ok := unwrap(sets[largest]).(interface{ cloneCap(int) Set[T] }); ok {
+		result = x.cloneCap(total)
+	} else {
+		result = sets[largest].Clone()
+	}
+	for i, s := range sets {
+		if i != largest {
+			result.UnionWith(s)
+		}
+	}
+	return result
+}
+
+// IntersectAll returns a new Set which is the intersection of all sets.
+// The result has the implementation of the smallest set.
+// Returns nil if no sets are given.
+func IntersectAll[T any](sets ...ReadOnlySet[T]) Set[T] {
+	switch len(sets) {
+	case 0:
+		return nil
+	case 1:
+		return sets[0].Clone()
+	}
+	sorted := slices.Clone(sets)
+	slices.SortStableFunc(sorted, func(a, b ReadOnlySet[T]) int {
+		return a.Cardinality() - b.Cardinality()
+	})
+	result := sorted[0].Intersection(sorted[1])
+	for _, s := range sorted[2:] {
+		if result.IsEmpty() {
+			break
+		}
+		result.IntersectWith(s)
+	}
+	return result
+}
+
+// DifferenceAll returns a new Set with all elements of base that are in none of the sets.
+// The result has the implementation of base.
+func DifferenceAll[T any](base ReadOnlySet[T], sets ...ReadOnlySet[T]) Set[T] {
+	result := base.Clone()
+	for _, s := range sets {
+		if result.IsEmpty() {
+			break
+		}
+		result.DifferenceWith(s)
+	}
+	return result
+}
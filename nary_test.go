@@ -0,0 +1,63 @@
+package set
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestUnionAll(t *testing.T) {
+	var tests = []struct {
+		sets []ReadOnlySet[int]
+		want []int
+	}{
+		{[]ReadOnlySet[int]{NewMapSet[int]()}, []int{}},
+		{[]ReadOnlySet[int]{NewMapSet(1, 2)}, []int{1, 2}},
+		{[]ReadOnlySet[int]{NewMapSet(1), NewTreeSet(2, 3), NewMapSet(3, 4)}, []int{1, 2, 3, 4}},
+	}
+	for i, test := range tests {
+		got := UnionAll(test.sets...).Elements()
+		sort.Ints(got)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+	}
+	if s := UnionAll[int](); s != nil {
+		t.Errorf("got %v, want nil", s)
+	}
+	if _, ok := UnionAll[int](NewMapSet(1), NewTreeSet(1, 2)).(*TreeSet[int]); !ok {
+		t.Error("result does not have the implementation of the largest set")
+	}
+}
+
+func TestIntersectAll(t *testing.T) {
+	var tests = []struct {
+		sets []ReadOnlySet[int]
+		want []int
+	}{
+		{[]ReadOnlySet[int]{NewMapSet(1, 2)}, []int{1, 2}},
+		{[]ReadOnlySet[int]{NewMapSet(1, 2, 3), NewTreeSet(2, 3), NewMapSet(3, 4, 2)}, []int{2, 3}},
+		{[]ReadOnlySet[int]{NewMapSet(1, 2), NewTreeSet(3), NewMapSet(1, 3)}, []int{}},
+	}
+	for i, test := range tests {
+		got := IntersectAll(test.sets...).Elements()
+		sort.Ints(got)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+	}
+	if s := IntersectAll[int](); s != nil {
+		t.Errorf("got %v, want nil", s)
+	}
+}
+
+func TestDifferenceAll(t *testing.T) {
+	got := DifferenceAll[int](NewTreeSet(1, 2, 3, 4), NewMapSet(1), NewTreeSet(3, 5)).Elements()
+	if want := []int{2, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	base := NewMapSet(1)
+	if s := DifferenceAll[int](base); !s.Equal(base) {
+		t.Errorf("got %v, want %v", s, base)
+	}
+}
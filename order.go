@@ -0,0 +1,80 @@
+package set
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+	"slices"
+)
+
+var errUnordered = errors.New("set: element type is not ordered")
+
+// compareAs returns cmp.Compare for E as a comparison function for T.
+// T and E must be the same type.
+func compareAs[E cmp.Ordered, T any]() func(T, T) int {
+	return any(cmp.Compare[E]).(func(T, T) int)
+}
+
+// orderedCmp returns a comparison function for T that uses [cmp.Compare]
+// if the underlying type of T is ordered (see [cmp.Ordered]).
+func orderedCmp[T any]() (func(T, T) int, bool) {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return compareAs[int, T](), true
+	case int8:
+		return compareAs[int8, T](), true
+	case int16:
+		return compareAs[int16, T](), true
+	case int32:
+		return compareAs[int32, T](), true
+	case int64:
+		return compareAs[int64, T](), true
+	case uint:
+		return compareAs[uint, T](), true
+	case uint8:
+		return compareAs[uint8, T](), true
+	case uint16:
+		return compareAs[uint16, T](), true
+	case uint32:
+		return compareAs[uint32, T](), true
+	case uint64:
+		return compareAs[uint64, T](), true
+	case uintptr:
+		return compareAs[uintptr, T](), true
+	case float32:
+		return compareAs[float32, T](), true
+	case float64:
+		return compareAs[float64, T](), true
+	case string:
+		return compareAs[string, T](), true
+	}
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int())
+		}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint())
+		}, true
+	case reflect.Float32, reflect.Float64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float())
+		}, true
+	case reflect.String:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+		}, true
+	}
+	return nil, false
+}
+
+// sortIfOrdered sorts elems with [cmp.Compare] if the underlying type of T
+// is ordered and returns elems.
+func sortIfOrdered[T any](elems []T) []T {
+	if cmp, ok := orderedCmp[T](); ok {
+		slices.SortFunc(elems, cmp)
+	}
+	return elems
+}
@@ -0,0 +1,113 @@
+package set
+
+import "iter"
+
+// readOnlySet is a read-only view of a Set.
+type readOnlySet[T any] struct {
+	s Set[T]
+}
+
+// ReadOnly returns a read-only view of s. The view is not a copy:
+// changes to s are visible through it.
+func ReadOnly[T any](s Set[T]) ReadOnlySet[T] {
+	return &readOnlySet[T]{s: s}
+}
+
+// unwrap returns the Set behind a read-only view or a FrozenSet, or s itself.
+func unwrap[T any](s ReadOnlySet[T]) ReadOnlySet[T] {
+	switch v := s.(type) {
+	case *readOnlySet[T]:
+		return v.s
+	case *FrozenSet[T]:
+		return v.s
+	}
+	return s
+}
+
+func (v *readOnlySet[T]) Contains(elem T) bool {
+	return v.s.Contains(elem)
+}
+
+func (v *readOnlySet[T]) IsEmpty() bool {
+	return v.s.IsEmpty()
+}
+
+func (v *readOnlySet[T]) Cardinality() int {
+	return v.s.Cardinality()
+}
+
+func (v *readOnlySet[T]) Any() (T, bool) {
+	return v.s.Any()
+}
+
+func (v *readOnlySet[T]) Union(s2 ReadOnlySet[T]) Set[T] {
+	return v.s.Union(s2)
+}
+
+func (v *readOnlySet[T]) Intersection(s2 ReadOnlySet[T]) Set[T] {
+	return v.s.Intersection(s2)
+}
+
+func (v *readOnlySet[T]) Difference(s2 ReadOnlySet[T]) Set[T] {
+	return v.s.Difference(s2)
+}
+
+func (v *readOnlySet[T]) SymDifference(s2 ReadOnlySet[T]) Set[T] {
+	return v.s.SymDifference(s2)
+}
+
+func (v *readOnlySet[T]) IntersectionCardinality(s2 ReadOnlySet[T]) int {
+	return v.s.IntersectionCardinality(s2)
+}
+
+func (v *readOnlySet[T]) UnionCardinality(s2 ReadOnlySet[T]) int {
+	return v.s.UnionCardinality(s2)
+}
+
+func (v *readOnlySet[T]) DifferenceCardinality(s2 ReadOnlySet[T]) int {
+	return v.s.DifferenceCardinality(s2)
+}
+
+func (v *readOnlySet[T]) IsDisjoint(s2 ReadOnlySet[T]) bool {
+	return v.s.IsDisjoint(s2)
+}
+
+func (v *readOnlySet[T]) Overlaps(s2 ReadOnlySet[T]) bool {
+	return v.s.Overlaps(s2)
+}
+
+func (v *readOnlySet[T]) IsSubset(s2 ReadOnlySet[T]) bool {
+	return v.s.IsSubset(s2)
+}
+
+func (v *readOnlySet[T]) IsProperSubset(s2 ReadOnlySet[T]) bool {
+	return v.s.IsProperSubset(s2)
+}
+
+func (v *readOnlySet[T]) IsSuperset(s2 ReadOnlySet[T]) bool {
+	return v.s.IsSuperset(s2)
+}
+
+func (v *readOnlySet[T]) IsProperSuperset(s2 ReadOnlySet[T]) bool {
+	return v.s.IsProperSuperset(s2)
+}
+
+func (v *readOnlySet[T]) Equal(s2 ReadOnlySet[T]) bool {
+	return v.s.Equal(s2)
+}
+
+func (v *readOnlySet[T]) Clone() Set[T] {
+	return v.s.Clone()
+}
+
+func (v *readOnlySet[T]) Elements() []T {
+	return v.s.Elements()
+}
+
+func (v *readOnlySet[T]) Iter() iter.Seq[T] {
+	return v.s.Iter()
+}
+
+func (v *readOnlySet[T]) String() string {
+	return v.s.String()
+}
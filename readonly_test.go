@@ -0,0 +1,44 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadOnly(t *testing.T) {
+	s := NewTreeSet(1, 2)
+	v := ReadOnly[int](s)
+	if _, ok := v.(Set[int]); ok {
+		t.Error("view implements Set")
+	}
+	s.Add(3)
+	if !v.Contains(3) {
+		t.Error("got false, want true")
+	}
+	if n := v.Cardinality(); n != 3 {
+		t.Errorf("got %d, want 3", n)
+	}
+	c := v.Clone()
+	c.Add(4)
+	if v.Contains(4) {
+		t.Error("got true, want false")
+	}
+	if got := v.String(); got != "TreeSet{1, 2, 3}" {
+		t.Errorf("got %q, want %q", got, "TreeSet{1, 2, 3}")
+	}
+}
+
+func TestReadOnlyOperand(t *testing.T) {
+	s := NewMapSet(1, 2)
+	v := ReadOnly[int](NewMapSet(2, 3))
+	want := map[int]struct{}{1: {}, 2: {}, 3: {}}
+	if got := s.Union(v).(*MapSet[int]).data; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := v.Union(s).(*MapSet[int]).data; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !s.SymDifference(v).Equal(NewTreeSet(1, 3)) {
+		t.Error("got false, want true")
+	}
+}
@@ -0,0 +1,53 @@
+package set
+
+// Relation describes how two sets relate to each other.
+type Relation int
+
+const (
+	// RelEqual means that both sets contain the same elements.
+	RelEqual Relation = iota
+	// RelSubset means that the first set is a proper subset of the second set.
+	RelSubset
+	// RelSuperset means that the first set is a proper superset of the second set.
+	RelSuperset
+	// RelDisjoint means that the sets have no elements in common.
+	RelDisjoint
+	// RelOverlap means that the sets have some but not all elements in common.
+	RelOverlap
+)
+
+// String returns the name of the Relation.
+func (r Relation) String() string {
+	switch r {
+	case RelEqual:
+		return "Equal"
+	case RelSubset:
+		return "Subset"
+	case RelSuperset:
+		return "Superset"
+	case RelDisjoint:
+		return "Disjoint"
+	case RelOverlap:
+		return "Overlap"
+	}
+	return "Relation(?)"
+}
+
+// Compare returns the Relation of a to b. The first matching Relation in the
+// order RelEqual, RelSubset, RelSuperset, RelDisjoint, RelOverlap is returned,
+// e.g. an empty set is a RelSubset of a non-empty set. The sets are walked only once.
+func Compare[T any](a, b ReadOnlySet[T]) Relation {
+	na, nb := a.Cardinality(), b.Cardinality()
+	n := a.IntersectionCardinality(b)
+	switch {
+	case n == na && n == nb:
+		return RelEqual
+	case n == na:
+		return RelSubset
+	case n == nb:
+		return RelSuperset
+	case n == 0:
+		return RelDisjoint
+	}
+	return RelOverlap
+}
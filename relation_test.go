@@ -0,0 +1,32 @@
+package set
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	var tests = []struct {
+		s1, s2 ReadOnlySet[int]
+		want   Relation
+	}{
+		{NewMapSet[int](), NewTreeSet[int](), RelEqual},
+		{NewMapSet(1, 2), NewTreeSet(1, 2), RelEqual},
+		{NewMapSet[int](), NewTreeSet(1), RelSubset},
+		{NewMapSet(1), NewTreeSet(1, 2), RelSubset},
+		{NewTreeSet(1, 2), NewTreeSet(2), RelSuperset},
+		{NewTreeSet(1, 2), NewMapSet(3, 4), RelDisjoint},
+		{NewTreeSet(1, 2), NewMapSet(2, 3), RelOverlap},
+	}
+	for i, test := range tests {
+		if got := Compare(test.s1, test.s2); got != test.want {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestRelationString(t *testing.T) {
+	if got := RelOverlap.String(); got != "Overlap" {
+		t.Errorf("got %q, want %q", got, "Overlap")
+	}
+	if got := Relation(42).String(); got != "Relation(?)" {
+		t.Errorf("got %q, want %q", got, "Relation(?)")
+	}
+}
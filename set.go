@@ -1,49 +1,79 @@
 // Package set implements [Set] types for Go.
+//
+// # Text format
+//
+// The text format of a Set is produced by String and MarshalText and read by
+// [ParseMapSet], [ParseTreeSet], [ParseTreeSetFunc] and UnmarshalText:
+//
+//	set     = [name] "{" [element {"," element}] "}"
+//	name    = "MapSet" | "TreeSet"
+//	element = bare | quoted
+//
+// A bare element is the text of the element as formatted by fmt with %v.
+// It must not be empty, must not start or end with white space, and must not
+// contain any of the characters , { } " \. All other elements are written as
+// quoted Go string literals (see [strconv.Quote]). White space around names,
+// braces, commas and elements is ignored when parsing.
 package set
 
 import "iter"
 
-type Set[T any] interface {
+// ReadOnlySet contains the methods of a [Set] that do not modify it.
+type ReadOnlySet[T any] interface {
 	// Contains reports whether the element is in the Set.
 	Contains(elem T) bool
 
-	// Add adds an element to the Set.
-	// Returns true if it was added, false if it already was in the set.
-	Add(elem T) bool
-
-	// Update updates the Set with elems.
-	Update(elems ...T)
-
-	// Remove removes an element from the Set.
-	// Returns true if it was in the set, false otherwise.
-	Remove(elem T) bool
-
 	// IsEmpty returns true if Set is an empty set.
 	IsEmpty() bool
 
 	// Cardinality returns the number of elements in the Set.
 	Cardinality() int
 
+	// Any returns an arbitrary element of the Set without removing it.
+	// Returns false if the Set is empty.
+	Any() (T, bool)
+
 	// Union returns a new Set which is the union of the Set and s2.
-	Union(s2 Set[T]) Set[T]
+	Union(s2 ReadOnlySet[T]) Set[T]
 
 	// Intersection returns a new Set which is the intersection of the Set and s2.
-	Intersection(s2 Set[T]) Set[T]
+	Intersection(s2 ReadOnlySet[T]) Set[T]
 
 	// Difference returns a new Set which is the set difference of the Set and s2.
-	Difference(s2 Set[T]) Set[T]
+	Difference(s2 ReadOnlySet[T]) Set[T]
 
 	// SymDifference returns a new Set which is the symmetric difference of the Set and s2.
-	SymDifference(s2 Set[T]) Set[T]
+	SymDifference(s2 ReadOnlySet[T]) Set[T]
+
+	// IntersectionCardinality returns the cardinality of the intersection of the Set and s2.
+	IntersectionCardinality(s2 ReadOnlySet[T]) int
+
+	// UnionCardinality returns the cardinality of the union of the Set and s2.
+	UnionCardinality(s2 ReadOnlySet[T]) int
+
+	// DifferenceCardinality returns the cardinality of the set difference of the Set and s2.
+	DifferenceCardinality(s2 ReadOnlySet[T]) int
+
+	// IsDisjoint returns true if the Set and s2 have no elements in common.
+	IsDisjoint(s2 ReadOnlySet[T]) bool
+
+	// Overlaps returns true if the Set and s2 have at least one element in common.
+	Overlaps(s2 ReadOnlySet[T]) bool
 
 	// IsSubset returns true if the Set is a subset of s2.
-	IsSubset(s2 Set[T]) bool
+	IsSubset(s2 ReadOnlySet[T]) bool
 
 	// IsProperSubset returns true if the Set is a proper subset of s2.
-	IsProperSubset(s2 Set[T]) bool
+	IsProperSubset(s2 ReadOnlySet[T]) bool
+
+	// IsSuperset returns true if the Set is a superset of s2.
+	IsSuperset(s2 ReadOnlySet[T]) bool
+
+	// IsProperSuperset returns true if the Set is a proper superset of s2.
+	IsProperSuperset(s2 ReadOnlySet[T]) bool
 
 	// Equal returns true if the Set and s2 contain the same elements.
-	Equal(s2 Set[T]) bool
+	Equal(s2 ReadOnlySet[T]) bool
 
 	// Clone clones the Set.
 	Clone() Set[T]
@@ -57,3 +87,53 @@ type Set[T any] interface {
 	// String returns a string representation of the Set.
 	String() string
 }
+
+// Set is a [ReadOnlySet] that can be modified.
+type Set[T any] interface {
+	ReadOnlySet[T]
+
+	// Add adds an element to the Set.
+	// Returns true if it was added, false if it already was in the set.
+	Add(elem T) bool
+
+	// Update updates the Set with elems.
+	Update(elems ...T)
+
+	// UpdateSeq updates the Set with the elements of seq.
+	UpdateSeq(seq iter.Seq[T])
+
+	// Remove removes an element from the Set.
+	// Returns true if it was in the set, false otherwise.
+	Remove(elem T) bool
+
+	// Clear removes all elements from the Set.
+	Clear()
+
+	// Pop removes and returns an arbitrary element of the Set.
+	// Returns false if the Set is empty.
+	Pop() (T, bool)
+
+	// RemoveIf removes all elements from the Set for which pred returns true.
+	// Returns the number of elements that were removed.
+	RemoveIf(pred func(T) bool) int
+
+	// RetainIf removes all elements from the Set for which pred returns false.
+	// Returns the number of elements that were removed.
+	RetainIf(pred func(T) bool) int
+
+	// UnionWith adds all elements of s2 to the Set.
+	// Returns the number of elements that were added.
+	UnionWith(s2 ReadOnlySet[T]) int
+
+	// IntersectWith removes all elements from the Set that are not in s2.
+	// Returns the number of elements that were removed.
+	IntersectWith(s2 ReadOnlySet[T]) int
+
+	// DifferenceWith removes all elements of s2 from the Set.
+	// Returns the number of elements that were removed.
+	DifferenceWith(s2 ReadOnlySet[T]) int
+
+	// SymDifferenceWith removes all elements of s2 from the Set that are in the Set
+	// and adds all others. Returns the number of elements that were added or removed.
+	SymDifferenceWith(s2 ReadOnlySet[T]) int
+}
@@ -0,0 +1,251 @@
+package set
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PGArray reads and writes a Set as PostgreSQL array literal, e.g. {a,"b c"}.
+// It implements the [sql.Scanner] and [driver.Valuer] interfaces.
+type PGArray[T any] struct {
+	set   Set[T]
+	parse func(string) (T, error)
+}
+
+// NewPGArray returns a new PGArray for s. Function parse converts the text of an
+// element to the element; if it is nil, [ParseElement] is used.
+func NewPGArray[T any](s Set[T], parse func(string) (T, error)) *PGArray[T] {
+	if parse == nil {
+		parse = ParseElement[T]
+	}
+	return &PGArray[T]{set: s, parse: parse}
+}
+
+// Value implements the [driver.Valuer] interface. It returns the array literal as string.
+// The elements are sorted if the underlying type of T is ordered.
+func (a *PGArray[T]) Value() (driver.Value, error) {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, elem := range sortIfOrdered(a.set.Elements()) {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		str := FormatElement(elem)
+		if pgNeedsQuote(str) {
+			sb.WriteByte('"')
+			for _, c := range []byte(str) {
+				if c == '"' || c == '\\' {
+					sb.WriteByte('\\')
+				}
+				sb.WriteByte(c)
+			}
+			sb.WriteByte('"')
+		} else {
+			sb.WriteString(str)
+		}
+	}
+	sb.WriteByte('}')
+	return sb.String(), nil
+}
+
+func pgNeedsQuote(str string) bool {
+	return str == "" || strings.EqualFold(str, "NULL") || strings.ContainsAny(str, "{}\",\\ \t\n\r\v\f")
+}
+
+// Scan implements the [sql.Scanner] interface. The elements of the Set are replaced
+// by the elements of the array literal in src, which must be a string or []byte.
+// SQL NULL results in an empty Set. NULL elements and multi-dimensional arrays are errors.
+func (a *PGArray[T]) Scan(src any) error {
+	var text string
+	switch src := src.(type) {
+	case nil:
+		a.set.Clear()
+		return nil
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	default:
+		return fmt.Errorf("set: cannot scan %T into PGArray", src)
+	}
+	a.set.Clear()
+	return parsePGArray(text, func(str string, pos int) error {
+		elem, err := a.parse(str)
+		if err != nil {
+			return &ParseError{Pos: pos, Err: err}
+		}
+		a.set.Add(elem)
+		return nil
+	})
+}
+
+// parsePGArray parses a one-dimensional PostgreSQL array literal and calls add for each element.
+func parsePGArray(text string, add func(str string, pos int) error) error {
+	fail := func(p int, format string, args ...any) error {
+		return &ParseError{Pos: p, Err: fmt.Errorf(format, args...)}
+	}
+	isSpace := func(c byte) bool {
+		return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+	}
+	pos := 0
+	if strings.HasPrefix(text, "[") {
+		// skip dimension decoration, e.g. [1:3]={1,2,3}
+		eq := strings.IndexByte(text, '=')
+		if eq < 0 {
+			return fail(0, "invalid dimension decoration")
+		}
+		pos = eq + 1
+	}
+	if pos >= len(text) || text[pos] != '{' {
+		return fail(pos, "missing {")
+	}
+	pos++
+	for pos < len(text) && isSpace(text[pos]) {
+		pos++
+	}
+	if pos < len(text) && text[pos] == '}' {
+		pos++
+	} else {
+		for {
+			for pos < len(text) && isSpace(text[pos]) {
+				pos++
+			}
+			if pos >= len(text) {
+				return fail(pos, "missing }")
+			}
+			start := pos
+			var str string
+			switch text[pos] {
+			case '{':
+				return fail(pos, "multi-dimensional arrays are not supported")
+			case '"':
+				var sb strings.Builder
+				pos++
+				for {
+					if pos >= len(text) {
+						return fail(start, "unterminated quoted element")
+					}
+					c := text[pos]
+					pos++
+					if c == '"' {
+						break
+					}
+					if c == '\\' {
+						if pos >= len(text) {
+							return fail(start, "unterminated quoted element")
+						}
+						c = text[pos]
+						pos++
+					}
+					sb.WriteByte(c)
+				}
+				str = sb.String()
+			default:
+				// trailing white space is removed unless it is escaped
+				var sb strings.Builder
+				escaped := false
+				keep := 0
+				for {
+					if pos >= len(text) {
+						return fail(len(text), "missing }")
+					}
+					c := text[pos]
+					if c == ',' || c == '}' {
+						break
+					}
+					pos++
+					if c == '\\' {
+						if pos >= len(text) {
+							return fail(len(text), "missing }")
+						}
+						sb.WriteByte(text[pos])
+						pos++
+						escaped = true
+						keep = sb.Len()
+						continue
+					}
+					sb.WriteByte(c)
+					if !isSpace(c) {
+						keep = sb.Len()
+					}
+				}
+				str = sb.String()[:keep]
+				if str == "" {
+					return fail(start, "empty element")
+				}
+				if !escaped && strings.EqualFold(str, "NULL") {
+					return fail(start, "NULL element")
+				}
+			}
+			if err := add(str, start); err != nil {
+				return err
+			}
+			for pos < len(text) && isSpace(text[pos]) {
+				pos++
+			}
+			if pos >= len(text) {
+				return fail(pos, "missing }")
+			}
+			c := text[pos]
+			pos++
+			if c == '}' {
+				break
+			}
+			if c != ',' {
+				return fail(pos-1, "unexpected %q", c)
+			}
+		}
+	}
+	if pos != len(text) {
+		return fail(pos, "unexpected text after }")
+	}
+	return nil
+}
+
+// JSONColumn reads and writes a Set as JSON array.
+// It implements the [sql.Scanner] and [driver.Valuer] interfaces.
+type JSONColumn[T any] struct {
+	set Set[T]
+}
+
+// NewJSONColumn returns a new JSONColumn for s.
+func NewJSONColumn[T any](s Set[T]) *JSONColumn[T] {
+	return &JSONColumn[T]{set: s}
+}
+
+// Value implements the [driver.Valuer] interface. It returns the JSON array as []byte.
+// The elements are sorted if the underlying type of T is ordered.
+func (c *JSONColumn[T]) Value() (driver.Value, error) {
+	return json.Marshal(sortIfOrdered(c.set.Elements()))
+}
+
+// Scan implements the [sql.Scanner] interface. The elements of the Set are replaced
+// by the elements of the JSON array in src, which must be a string or []byte.
+// SQL NULL results in an empty Set.
+func (c *JSONColumn[T]) Scan(src any) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		c.set.Clear()
+		return nil
+	case string:
+		data = []byte(src)
+	case []byte:
+		data = src
+	default:
+		return fmt.Errorf("set: cannot scan %T into JSONColumn", src)
+	}
+	c.set.Clear()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := DecodeJSON(dec, c.set, false); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("set: unexpected data after JSON array at offset %d", dec.InputOffset())
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package set
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var (
+	_ sql.Scanner   = (*PGArray[int])(nil)
+	_ driver.Valuer = (*PGArray[int])(nil)
+	_ sql.Scanner   = (*JSONColumn[int])(nil)
+	_ driver.Valuer = (*JSONColumn[int])(nil)
+)
+
+func TestPGArrayValue(t *testing.T) {
+	var tests = []struct {
+		s    Set[string]
+		want string
+	}{
+		{NewMapSet[string](), "{}"},
+		{NewMapSet("b", "a"), "{a,b}"},
+		{NewTreeSet("", "null", "a b", `q"\`, "x,y", "{}"), `{"","a b","null","q\"\\","x,y","{}"}`},
+	}
+	for i, test := range tests {
+		got, err := NewPGArray(test.s, nil).Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("%d: got %s, want %s", i, got, test.want)
+		}
+	}
+}
+
+func TestPGArrayScan(t *testing.T) {
+	var tests = []struct {
+		src  any
+		want []string
+	}{
+		{nil, []string{}},
+		{"{}", []string{}},
+		{[]byte("{a,b}"), []string{"a", "b"}},
+		{`{ "a b" , c ,"q\"\\"}`, []string{"a b", "c", `q"\`}},
+		{"[1:2]={x,y}", []string{"x", "y"}},
+		{`{"NULL"}`, []string{"NULL"}},
+		{`{a\,b,c}`, []string{"a,b", "c"}},
+		{`{\NULL, a\ \\ , \"b}`, []string{"\"b", "NULL", `a \`}},
+	}
+	for i, test := range tests {
+		s := NewTreeSet("old")
+		if err := NewPGArray[string](s, nil).Scan(test.src); err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if got := s.Elements(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestPGArrayRoundTrip(t *testing.T) {
+	s := NewTreeSet("", "null", "a b", `q"\`, "x,y", "{}", "ä")
+	v, _ := NewPGArray[string](s, nil).Value()
+	s2 := NewTreeSet[string]()
+	if err := NewPGArray[string](s2, nil).Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !s2.Equal(s) {
+		t.Errorf("got %v, want %v", s2, s)
+	}
+	ints := NewMapSet(3, 1, 2)
+	v, _ = NewPGArray[int](ints, nil).Value()
+	if v != "{1,2,3}" {
+		t.Errorf("got %v, want {1,2,3}", v)
+	}
+	ints2 := NewMapSet[int]()
+	if err := NewPGArray[int](ints2, strconv.Atoi).Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !ints2.Equal(ints) {
+		t.Errorf("got %v, want %v", ints2, ints)
+	}
+	durs := NewTreeSet(time.Second, time.Minute)
+	v, _ = NewPGArray[time.Duration](durs, nil).Value()
+	durs2 := NewTreeSet[time.Duration]()
+	if err := NewPGArray[time.Duration](durs2, nil).Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !durs2.Equal(durs) {
+		t.Errorf("got %v, want %v", durs2, durs)
+	}
+}
+
+func TestPGArrayErrors(t *testing.T) {
+	var tests = []struct {
+		src any
+		pos int
+	}{
+		{"1,2", 0},
+		{"{1,2", 4},
+		{"{1,NULL}", 3},
+		{"{{1},{2}}", 1},
+		{`{"1}`, 1},
+		{"{1,,2}", 3},
+		{"{1,x}", 3},
+		{"{1} x", 3},
+		{`{1\`, 3},
+	}
+	for i, test := range tests {
+		err := NewPGArray[int](NewMapSet[int](), nil).Scan(test.src)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%d: got %v, want ParseError", i, err)
+			continue
+		}
+		if perr.Pos != test.pos {
+			t.Errorf("%d: got position %d, want %d (%v)", i, perr.Pos, test.pos, err)
+		}
+	}
+	if err := NewPGArray[int](NewMapSet[int](), nil).Scan(42); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+func TestJSONColumn(t *testing.T) {
+	s := NewMapSet(3, 1, 2)
+	v, err := NewJSONColumn[int](s).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(v.([]byte)) != "[1,2,3]" {
+		t.Errorf("got %s, want [1,2,3]", v)
+	}
+	s2 := NewTreeSet(9)
+	if err := NewJSONColumn[int](s2).Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s2.Elements(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := NewJSONColumn[int](s2).Scan(nil); err != nil || !s2.IsEmpty() {
+		t.Errorf("got %v and %v, want nil and empty set", err, s2)
+	}
+	if err := NewJSONColumn[int](s2).Scan("[4] \n"); err != nil || !s2.Equal(NewTreeSet(4)) {
+		t.Errorf("got %v and %v, want nil and {4}", err, s2)
+	}
+	for i, src := range []any{"[1] [2]", "[1,2]]", "[1,2] }", `{"a":1}`, 42} {
+		if err := NewJSONColumn[int](s2).Scan(src); err == nil {
+			t.Errorf("%d: got nil, want error", i)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package set
+
+import (
+	"cmp"
+	"encoding"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseError is returned if the text representation of a Set cannot be parsed.
+type ParseError struct {
+	Pos int   // byte offset in the input
+	Err error // the underlying error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("set: parse error at position %d: %v", e.Pos, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// FormatElement converts elem to text that [ParseElement] converts back to
+// elem. If *T implements [encoding.TextMarshaler], it is used. Strings, bools,
+// integers and floating-point numbers are formatted with strconv; other types
+// and elements whose MarshalText method fails are formatted with the %v verb.
+func FormatElement[T any](elem T) string {
+	if m, ok := any(&elem).(encoding.TextMarshaler); ok {
+		if b, err := m.MarshalText(); err == nil {
+			return string(b)
+		}
+		return fmt.Sprintf("%v", elem)
+	}
+	rv := reflect.ValueOf(&elem).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits())
+	}
+	return fmt.Sprintf("%v", elem)
+}
+
+// formatElement returns the text format of elem.
+func formatElement[T any](elem T) string {
+	str := FormatElement(elem)
+	if needsQuote(str) {
+		return strconv.Quote(str)
+	}
+	return str
+}
+
+func needsQuote(str string) bool {
+	if str == "" || strings.ContainsAny(str, `,{}"\`) {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(str)
+	last, _ := utf8.DecodeLastRuneInString(str)
+	return unicode.IsSpace(first) || unicode.IsSpace(last)
+}
+
+// formatSet returns the text format of a Set with the given name and n elements.
+func formatSet[T any](name string, n int, seq iter.Seq[T]) string {
+	sl := make([]string, 0, n)
+	for elem := range seq {
+		sl = append(sl, formatElement(elem))
+	}
+	return fmt.Sprintf("%s{%s}", name, strings.Join(sl, ", "))
+}
+
+// parseSet parses text and calls add for each element.
+func parseSet[T any](text string, parse func(string) (T, error), add func(T)) error {
+	pos := 0
+	skipSpace := func() {
+		for pos < len(text) {
+			r, n := utf8.DecodeRuneInString(text[pos:])
+			if !unicode.IsSpace(r) {
+				return
+			}
+			pos += n
+		}
+	}
+	fail := func(p int, format string, args ...any) error {
+		return &ParseError{Pos: p, Err: fmt.Errorf(format, args...)}
+	}
+	skipSpace()
+	brace := strings.IndexByte(text[pos:], '{')
+	if brace < 0 {
+		return fail(len(text), "missing {")
+	}
+	if name := strings.TrimSpace(text[pos : pos+brace]); name != "" && name != "MapSet" && name != "TreeSet" {
+		return fail(pos, "unknown set type %q", name)
+	}
+	pos += brace + 1
+	skipSpace()
+	if pos < len(text) && text[pos] == '}' {
+		pos++
+	} else {
+		for {
+			skipSpace()
+			start := pos
+			var str string
+			if pos < len(text) && text[pos] == '"' {
+				quoted, err := strconv.QuotedPrefix(text[pos:])
+				if err != nil {
+					return fail(start, "invalid quoted element")
+				}
+				str, _ = strconv.Unquote(quoted)
+				pos += len(quoted)
+			} else {
+				end := strings.IndexAny(text[pos:], `,{}"\`)
+				if end < 0 {
+					return fail(len(text), "missing }")
+				}
+				if text[pos+end] != ',' && text[pos+end] != '}' {
+					return fail(pos+end, "unexpected %q", text[pos+end])
+				}
+				str = strings.TrimRightFunc(text[pos:pos+end], unicode.IsSpace)
+				pos += end
+				if str == "" {
+					return fail(start, "empty element")
+				}
+			}
+			elem, err := parse(str)
+			if err != nil {
+				return &ParseError{Pos: start, Err: err}
+			}
+			add(elem)
+			skipSpace()
+			if pos >= len(text) {
+				return fail(pos, "missing }")
+			}
+			c := text[pos]
+			pos++
+			if c == '}' {
+				break
+			}
+			if c != ',' {
+				return fail(pos-1, "unexpected %q", c)
+			}
+		}
+	}
+	skipSpace()
+	if pos < len(text) {
+		return fail(pos, "unexpected text after }")
+	}
+	return nil
+}
+
+// ParseMapSet parses the text format of a Set (see [MapSet.String]).
+// Function parse converts the text of an element to the element.
+func ParseMapSet[T comparable](text string, parse func(string) (T, error)) (*MapSet[T], error) {
+	s := NewMapSet[T]()
+	if err := parseSet(text, parse, func(elem T) { s.data[elem] = struct{}{} }); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseTreeSet parses the text format of a Set (see [TreeSet.String]).
+// Function parse converts the text of an element to the element.
+func ParseTreeSet[T cmp.Ordered](text string, parse func(string) (T, error)) (*TreeSet[T], error) {
+	s := NewTreeSet[T]()
+	if err := parseSet(text, parse, func(elem T) { s.tree.Add(elem) }); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseTreeSetFunc parses the text format of a Set (see [TreeSet.String]).
+// Function cmp is used to compare two elements (see [NewTreeSetFunc]),
+// function parse converts the text of an element to the element.
+func ParseTreeSetFunc[T any](text string, cmp func(T, T) int, parse func(string) (T, error)) (*TreeSet[T], error) {
+	s := NewTreeSetFunc(cmp)
+	if err := parseSet(text, parse, func(elem T) { s.tree.Add(elem) }); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseElement converts text to an element of type T (see [FormatElement]).
+// It is the default for functions that parse elements. If *T implements [encoding.TextUnmarshaler],
+// it is used. Otherwise the underlying type of T must be a string, bool,
+// integer or floating-point type; integers may have a base prefix.
+func ParseElement[T any](text string) (T, error) {
+	var elem T
+	if u, ok := any(&elem).(encoding.TextUnmarshaler); ok {
+		err := u.UnmarshalText([]byte(text))
+		return elem, err
+	}
+	rv := reflect.ValueOf(&elem).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return elem, err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, 0, rv.Type().Bits())
+		if err != nil {
+			return elem, err
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(text, 0, rv.Type().Bits())
+		if err != nil {
+			return elem, err
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, rv.Type().Bits())
+		if err != nil {
+			return elem, err
+		}
+		rv.SetFloat(f)
+	default:
+		return elem, fmt.Errorf("cannot parse element of type %T", elem)
+	}
+	return elem, nil
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// The elements are sorted if the underlying type of T is ordered.
+func (s MapSet[T]) MarshalText() ([]byte, error) {
+	return []byte(s.SortedString()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// The elements of the Set are replaced by the parsed elements (see [ParseElement]).
+func (s *MapSet[T]) UnmarshalText(text []byte) error {
+	s.Clear()
+	return parseSet(string(text), ParseElement[T], func(elem T) { s.Add(elem) })
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (s TreeSet[T]) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// The elements of the Set are replaced by the parsed elements (see [ParseElement]).
+func (s *TreeSet[T]) UnmarshalText(text []byte) error {
+	s.Clear()
+	return parseSet(string(text), ParseElement[T], func(elem T) { s.Add(elem) })
+}
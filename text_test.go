@@ -0,0 +1,251 @@
+package set
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"math"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	var tests = [][]string{
+		{},
+		{"a"},
+		{"a b", "c"},
+		{"", " x", "y ", "a,b", "{}", `"q"`, `back\slash`, "ä"},
+	}
+	for i, test := range tests {
+		s := NewTreeSet(test...)
+		got, err := ParseTreeSet(s.String(), parseString)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if !got.Equal(s) {
+			t.Errorf("%d: got %v, want %v", i, got, s)
+		}
+		m := NewMapSet(test...)
+		got2, err := ParseMapSet(m.String(), parseString)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if !reflect.DeepEqual(got2.data, m.data) {
+			t.Errorf("%d: got %v, want %v", i, got2, m)
+		}
+	}
+}
+
+func TestStringQuoted(t *testing.T) {
+	s := NewTreeSet("", "a", "b,c")
+	if got, want := s.String(), `TreeSet{"", a, "b,c"}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestParseTreeSet(t *testing.T) {
+	var tests = []struct {
+		text string
+		want []int
+	}{
+		{"{}", []int{}},
+		{" TreeSet { } ", []int{}},
+		{"MapSet{3,1, 2}", []int{1, 2, 3}},
+		{`{"1" , 2 }`, []int{1, 2}},
+	}
+	for i, test := range tests {
+		s, err := ParseTreeSet(test.text, strconv.Atoi)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if got := s.Elements(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var tests = []struct {
+		text string
+		pos  int
+	}{
+		{"", 0},
+		{"1, 2", 4},
+		{"Set{1}", 0},
+		{"{1, 2", 5},
+		{"{1,, 2}", 3},
+		{"{1, x}", 4},
+		{`{1, "2}`, 4},
+		{"{1 2}", 1},
+		{"{1} x", 4},
+		{"{1{}", 2},
+	}
+	for i, test := range tests {
+		_, err := ParseTreeSet(test.text, strconv.Atoi)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%d: got %v, want ParseError", i, err)
+			continue
+		}
+		if perr.Pos != test.pos {
+			t.Errorf("%d: got position %d, want %d (%v)", i, perr.Pos, test.pos, err)
+		}
+	}
+	_, err := ParseMapSet("{1, x}", strconv.Atoi)
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("got %v, want %v", err, strconv.ErrSyntax)
+	}
+}
+
+func TestParseTreeSetFunc(t *testing.T) {
+	s, err := ParseTreeSetFunc("{1, 3, 2}", func(a, b int) int { return b - a }, strconv.Atoi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Elements(), []int{3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	got, err := NewMapSet(3, 1, 2).MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "MapSet{1, 2, 3}"; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	var s MapSet[netip.Addr]
+	if err := s.UnmarshalText([]byte("{127.0.0.1, ::1}")); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Contains(netip.MustParseAddr("::1")) || s.Cardinality() != 2 {
+		t.Errorf("got %v, want {127.0.0.1, ::1}", &s)
+	}
+	var ts TreeSet[float64]
+	if err := ts.UnmarshalText([]byte("{1.5, -2}")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ts.Elements(), []float64{-2, 1.5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	var bs TreeSet[struct{}]
+	if err := bs.UnmarshalText([]byte("{x}")); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+func TestMarshalTextValue(t *testing.T) {
+	var _ encoding.TextMarshaler = MapSet[int]{}
+	var _ encoding.TextMarshaler = TreeSet[int]{}
+	var v struct {
+		XMLName xml.Name `xml:"v"`
+		M       MapSet[int]
+		T       TreeSet[string]
+	}
+	v.M.Update(2, 1)
+	v.T.Add("a")
+	got, err := xml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<v><M>MapSet{1, 2}</M><T>TreeSet{a}</T></v>"; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestTextMapKey(t *testing.T) {
+	m := map[*TreeSet[int]]int{NewTreeSet(2, 1): 1}
+	got, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"TreeSet{1, 2}":1}`; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestParseElement(t *testing.T) {
+	if got, err := ParseElement[int]("0x10"); err != nil || got != 16 {
+		t.Errorf("got %v and %v, want 16 and nil", got, err)
+	}
+	if got, err := ParseElement[netip.Addr]("::1"); err != nil || got != netip.IPv6Loopback() {
+		t.Errorf("got %v and %v, want ::1 and nil", got, err)
+	}
+	type name string
+	if got, err := ParseElement[name]("x y"); err != nil || got != "x y" {
+		t.Errorf("got %v and %v, want x y and nil", got, err)
+	}
+	if _, err := ParseElement[bool]("maybe"); err == nil {
+		t.Error("got nil, want error")
+	}
+	if _, err := ParseElement[[]int]("1"); err == nil {
+		t.Error("got nil, want error")
+	}
+}
+
+type level int
+
+func (l level) String() string {
+	return "level" + strconv.Itoa(int(l))
+}
+
+func TestFormatElement(t *testing.T) {
+	tests := []struct {
+		elem any
+		want string
+	}{
+		{FormatElement(time.Second), "1000000000"},
+		{FormatElement(level(2)), "2"},
+		{FormatElement(float32(0.1)), "0.1"},
+		{FormatElement(true), "true"},
+		{FormatElement(uint8(255)), "255"},
+		{FormatElement(netip.IPv6Loopback()), "::1"},
+		{FormatElement([]int{1}), "[1]"},
+	}
+	for _, test := range tests {
+		if test.elem != test.want {
+			t.Errorf("got %v, want %s", test.elem, test.want)
+		}
+	}
+	for _, elem := range []float32{0.1, 1e30, float32(math.Inf(-1))} {
+		if got, err := ParseElement[float32](FormatElement(elem)); err != nil || got != elem {
+			t.Errorf("got %v and %v, want %v and nil", got, err, elem)
+		}
+	}
+}
+
+func TestMarshalTextRoundTrip(t *testing.T) {
+	s := NewTreeSet(time.Second, time.Millisecond)
+	text, err := s.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got TreeSet[time.Duration]
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(s) {
+		t.Errorf("got %v, want %v", &got, s)
+	}
+	m := NewMapSet[level](1, 2)
+	text, err = m.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotm MapSet[level]
+	if err := gotm.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !gotm.Equal(m) {
+		t.Errorf("got %v, want %v", &gotm, m)
+	}
+}
@@ -1,9 +1,7 @@
 package set
 
 import (
-	"fmt"
 	"iter"
-	"strings"
 
 	"cmp"
 
@@ -12,14 +10,24 @@ import (
 
 // TreeSet type that implements the [Set] interface.
 // It uses an AVL tree to store the elements.
+//
+// The zero value is an empty set ready to use. It orders its elements with
+// [cmp.Compare] if the underlying type of T is ordered (see [cmp.Ordered]);
+// for other types adding an element to it panics. A nil *TreeSet behaves like
+// an empty set for all methods that do not add elements, except for the
+// methods with value receivers (MarshalJSON, MarshalText, MarshalBinary and
+// GobEncode), which panic.
 type TreeSet[T any] struct {
-	tree *avltree.Tree[T]
-	cmp  avltree.Cmp[T]
+	tree    *avltree.Tree[T]
+	cmp     avltree.Cmp[T]
+	ordered bool // cmp is the natural order of T
 }
 
 // NewTreeSet returns a new TreeSet with the given elements.
 func NewTreeSet[T cmp.Ordered](elems ...T) *TreeSet[T] {
-	return NewTreeSetFunc(avltree.CmpOrd[T], elems...)
+	s := NewTreeSetFunc(avltree.CmpOrd[T], elems...)
+	s.ordered = true
+	return s
 }
 
 // NewTreeSetFunc returns a new TreeSet with the given elements. Function cmp is used
@@ -32,14 +40,60 @@ func NewTreeSetFunc[T any](cmp func(T, T) int, elems ...T) *TreeSet[T] {
 	return &TreeSet[T]{tree: tree, cmp: cmp}
 }
 
+// comparator returns the comparison function of s and whether it is the
+// natural order of T. A nil or zero TreeSet uses the default comparator.
+func (s *TreeSet[T]) comparator() (avltree.Cmp[T], bool) {
+	if s != nil && s.cmp != nil {
+		return s.cmp, s.ordered
+	}
+	if cmp, ok := orderedCmp[T](); ok {
+		return cmp, true
+	}
+	return func(a, b T) int {
+		panic(errUnordered)
+	}, false
+}
+
+// t returns the tree of the Set; it is a new empty tree for a nil or zero TreeSet.
+func (s *TreeSet[T]) t() *avltree.Tree[T] {
+	if s == nil || s.tree == nil {
+		return s.newTree()
+	}
+	return s.tree
+}
+
+// init allocates the tree of a zero TreeSet.
+func (s *TreeSet[T]) init() {
+	if s.tree == nil {
+		cmp, ordered := s.comparator()
+		if s.cmp == nil && !ordered {
+			panic(errUnordered)
+		}
+		s.tree, s.cmp, s.ordered = avltree.New(cmp, true), cmp, ordered
+	}
+}
+
+// newTree returns a new empty tree with the order of s.
+func (s *TreeSet[T]) newTree() *avltree.Tree[T] {
+	cmp, _ := s.comparator()
+	return avltree.New(cmp, true)
+}
+
+// withTree returns a new TreeSet with the order of s that uses tree.
+func (s *TreeSet[T]) withTree(tree *avltree.Tree[T]) *TreeSet[T] {
+	cmp, ordered := s.comparator()
+	return &TreeSet[T]{tree: tree, cmp: cmp, ordered: ordered}
+}
+
 // Contains reports whether the element is in the Set.
 func (s *TreeSet[T]) Contains(elem T) bool {
-	return s.tree.Contains(elem)
+	return s.t().Contains(elem)
 }
 
 // Add adds an element to the Set.
 // Returns true if it was added, false if it already was in the set.
 func (s *TreeSet[T]) Add(elem T) bool {
+	s.init()
 	return s.tree.Add(elem)
 }
 
@@ -50,30 +104,87 @@ func (s *TreeSet[T]) Update(elems ...T) {
 	}
 }
 
+// UpdateSeq updates the Set with the elements of seq.
+func (s *TreeSet[T]) UpdateSeq(seq iter.Seq[T]) {
+	for elem := range seq {
+		s.Add(elem)
+	}
+}
+
 // Remove removes an element from the Set.
 // Returns true if it was in the set, false otherwise.
 func (s *TreeSet[T]) Remove(elem T) bool {
-	return s.tree.Del(elem)
+	return s.t().Del(elem)
+}
+
+// Clear removes all elements from the Set.
+func (s *TreeSet[T]) Clear() {
+	if s != nil && s.tree != nil {
+		s.tree = avltree.New(s.cmp, true)
+	}
+}
+
+// Pop removes and returns the smallest element of the Set.
+// Returns false if the Set is empty.
+func (s *TreeSet[T]) Pop() (T, bool) {
+	elem, ok := s.Any()
+	if ok {
+		s.tree.Del(elem)
+	}
+	return elem, ok
+}
+
+// RemoveIf removes all elements from the Set for which pred returns true.
+// Returns the number of elements that were removed.
+func (s *TreeSet[T]) RemoveIf(pred func(T) bool) int {
+	var remove []T
+	s.t().Each(func(elem T) {
+		if pred(elem) {
+			remove = append(remove, elem)
+		}
+	})
+	for _, elem := range remove {
+		s.t().Del(elem)
+	}
+	return len(remove)
+}
+
+// RetainIf removes all elements from the Set for which pred returns false.
+// Returns the number of elements that were removed.
+func (s *TreeSet[T]) RetainIf(pred func(T) bool) int {
+	return s.RemoveIf(func(elem T) bool {
+		return !pred(elem)
+	})
 }
 
 // IsEmpty returns true if Set is an empty set.
 func (s *TreeSet[T]) IsEmpty() bool {
-	return s.tree.IsEmpty()
+	return s.t().IsEmpty()
 }
 
 // Cardinality returns the number of elements in the Set.
 func (s *TreeSet[T]) Cardinality() int {
-	return s.tree.Count()
+	return s.t().Count()
+}
+
+// Any returns the smallest element of the Set without removing it.
+// Returns false if the Set is empty.
+func (s *TreeSet[T]) Any() (T, bool) {
+	for elem := range s.t().Iter() {
+		return elem, true
+	}
+	var zero T
+	return zero, false
 }
 
 // Union returns a new Set which is the union of s and s2.
-func (s *TreeSet[T]) Union(s2 Set[T]) Set[T] {
-	tree := avltree.New(s.cmp, true)
-	s.tree.Each(func(elem T) {
+func (s *TreeSet[T]) Union(s2 ReadOnlySet[T]) Set[T] {
+	tree := s.newTree()
+	s.t().Each(func(elem T) {
 		tree.Add(elem)
 	})
-	if x, ok := s2.(*TreeSet[T]); ok {
-		x.tree.Each(func(elem T) {
+	if x, ok := unwrap(s2).(*TreeSet[T]); ok {
+		x.t().Each(func(elem T) {
 			tree.Add(elem)
 		})
 	} else {
@@ -81,41 +192,41 @@ func (s *TreeSet[T]) Union(s2 Set[T]) Set[T] {
 			tree.Add(elem)
 		}
 	}
-	return &TreeSet[T]{tree: tree, cmp: s.cmp}
+	return s.withTree(tree)
 }
 
 // Intersection returns a new Set which is the intersection of s and s2.
-func (s *TreeSet[T]) Intersection(s2 Set[T]) Set[T] {
-	tree := avltree.New(s.cmp, true)
-	s.tree.Each(func(elem T) {
+func (s *TreeSet[T]) Intersection(s2 ReadOnlySet[T]) Set[T] {
+	tree := s.newTree()
+	s.t().Each(func(elem T) {
 		if s2.Contains(elem) {
 			tree.Add(elem)
 		}
 	})
-	return &TreeSet[T]{tree: tree, cmp: s.cmp}
+	return s.withTree(tree)
 }
 
 // Difference returns a new Set which is the set difference of s and s2.
-func (s *TreeSet[T]) Difference(s2 Set[T]) Set[T] {
-	tree := avltree.New(s.cmp, true)
-	s.tree.Each(func(elem T) {
+func (s *TreeSet[T]) Difference(s2 ReadOnlySet[T]) Set[T] {
+	tree := s.newTree()
+	s.t().Each(func(elem T) {
 		if !s2.Contains(elem) {
 			tree.Add(elem)
 		}
 	})
-	return &TreeSet[T]{tree: tree, cmp: s.cmp}
+	return s.withTree(tree)
 }
 
 // SymDifference returns a new Set which is the symmetric difference of s and s2.
-func (s *TreeSet[T]) SymDifference(s2 Set[T]) Set[T] {
-	tree := avltree.New(s.cmp, true)
-	s.tree.Each(func(elem T) {
+func (s *TreeSet[T]) SymDifference(s2 ReadOnlySet[T]) Set[T] {
+	tree := s.newTree()
+	s.t().Each(func(elem T) {
 		if !s2.Contains(elem) {
 			tree.Add(elem)
 		}
 	})
-	if x, ok := s2.(*TreeSet[T]); ok {
-		x.tree.Each(func(elem T) {
+	if x, ok := unwrap(s2).(*TreeSet[T]); ok {
+		x.t().Each(func(elem T) {
 			if !s.Contains(elem) {
 				tree.Add(elem)
 			}
@@ -127,31 +238,165 @@ func (s *TreeSet[T]) SymDifference(s2 Set[T]) Set[T] {
 			}
 		}
 	}
-	return &TreeSet[T]{tree: tree, cmp: s.cmp}
+	return s.withTree(tree)
+}
+
+// UnionWith adds all elements of s2 to s.
+// Returns the number of elements that were added.
+func (s *TreeSet[T]) UnionWith(s2 ReadOnlySet[T]) int {
+	s.init()
+	n := 0
+	for _, elem := range s2.Elements() {
+		if s.tree.Add(elem) {
+			n++
+		}
+	}
+	return n
+}
+
+// IntersectWith removes all elements from s that are not in s2.
+// Returns the number of elements that were removed.
+func (s *TreeSet[T]) IntersectWith(s2 ReadOnlySet[T]) int {
+	return s.RemoveIf(func(elem T) bool {
+		return !s2.Contains(elem)
+	})
+}
+
+// DifferenceWith removes all elements of s2 from s.
+// Returns the number of elements that were removed.
+func (s *TreeSet[T]) DifferenceWith(s2 ReadOnlySet[T]) int {
+	n := 0
+	for _, elem := range s2.Elements() {
+		if s.t().Del(elem) {
+			n++
+		}
+	}
+	return n
+}
+
+// SymDifferenceWith removes all elements of s2 from s that are in s
+// and adds all others. Returns the number of elements that were added or removed.
+func (s *TreeSet[T]) SymDifferenceWith(s2 ReadOnlySet[T]) int {
+	s.init()
+	elems := s2.Elements()
+	for _, elem := range elems {
+		if !s.tree.Del(elem) {
+			s.tree.Add(elem)
+		}
+	}
+	return len(elems)
+}
+
+// IntersectionCardinality returns the cardinality of the intersection of s and s2.
+func (s *TreeSet[T]) IntersectionCardinality(s2 ReadOnlySet[T]) int {
+	if x, ok := s.mergeable(s2); ok {
+		return s.mergeCount(x, 0)
+	}
+	return countCommon[T](s, s2, 0)
+}
+
+// UnionCardinality returns the cardinality of the union of s and s2.
+func (s *TreeSet[T]) UnionCardinality(s2 ReadOnlySet[T]) int {
+	return s.Cardinality() + s2.Cardinality() - s.IntersectionCardinality(s2)
+}
+
+// DifferenceCardinality returns the cardinality of the set difference of s and s2.
+func (s *TreeSet[T]) DifferenceCardinality(s2 ReadOnlySet[T]) int {
+	return s.Cardinality() - s.IntersectionCardinality(s2)
+}
+
+// IsDisjoint returns true if s and s2 have no elements in common.
+func (s *TreeSet[T]) IsDisjoint(s2 ReadOnlySet[T]) bool {
+	if x, ok := s.mergeable(s2); ok {
+		return s.mergeCount(x, 1) == 0
+	}
+	return countCommon[T](s, s2, 1) == 0
+}
+
+// Overlaps returns true if s and s2 have at least one element in common.
+func (s *TreeSet[T]) Overlaps(s2 ReadOnlySet[T]) bool {
+	return !s.IsDisjoint(s2)
+}
+
+// mergeable returns s2 as a TreeSet if both sets are in natural order.
+func (s *TreeSet[T]) mergeable(s2 ReadOnlySet[T]) (*TreeSet[T], bool) {
+	x, ok := unwrap(s2).(*TreeSet[T])
+	if !ok || s == nil || x == nil || !s.ordered || !x.ordered {
+		return nil, false
+	}
+	return x, true
+}
+
+// mergeCount counts the common elements of s and x by walking both trees in order.
+// If limit > 0 it stops after limit elements.
+func (s *TreeSet[T]) mergeCount(x *TreeSet[T], limit int) int {
+	if s.t().IsEmpty() || x.t().IsEmpty() {
+		return 0
+	}
+	next, stop := iter.Pull(x.t().Iter())
+	defer stop()
+	n := 0
+	b, ok := next()
+	for a := range s.t().Iter() {
+		for ok && s.cmp(b, a) < 0 {
+			b, ok = next()
+		}
+		if !ok {
+			break
+		}
+		if s.cmp(a, b) == 0 {
+			n++
+			if n == limit {
+				break
+			}
+		}
+	}
+	return n
 }
 
 // IsSubset returns true if s is a subset of s2.
-func (s *TreeSet[T]) IsSubset(s2 Set[T]) bool {
+func (s *TreeSet[T]) IsSubset(s2 ReadOnlySet[T]) bool {
 	if s.Cardinality() > s2.Cardinality() {
 		return false
 	}
 	b := true
-	s.tree.Each(func(elem T) {
+	s.t().Each(func(elem T) {
 		b = b && s2.Contains(elem)
 	})
 	return b
 }
 
 // IsProperSubset returns true if s is a proper subset of s2.
-func (s *TreeSet[T]) IsProperSubset(s2 Set[T]) bool {
+func (s *TreeSet[T]) IsProperSubset(s2 ReadOnlySet[T]) bool {
 	if s.Cardinality() >= s2.Cardinality() {
 		return false
 	}
 	return s.IsSubset(s2)
 }
 
+// IsSuperset returns true if s is a superset of s2.
+func (s *TreeSet[T]) IsSuperset(s2 ReadOnlySet[T]) bool {
+	if s.Cardinality() < s2.Cardinality() {
+		return false
+	}
+	for elem := range s2.Iter() {
+		if !s.t().Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsProperSuperset returns true if s is a proper superset of s2.
+func (s *TreeSet[T]) IsProperSuperset(s2 ReadOnlySet[T]) bool {
+	if s.Cardinality() <= s2.Cardinality() {
+		return false
+	}
+	return s.IsSuperset(s2)
+}
+
 // Equal returns true if s and s2 contain the same elements.
-func (s *TreeSet[T]) Equal(s2 Set[T]) bool {
+func (s *TreeSet[T]) Equal(s2 ReadOnlySet[T]) bool {
 	if s.Cardinality() != s2.Cardinality() {
 		return false
 	}
@@ -160,24 +405,21 @@ func (s *TreeSet[T]) Equal(s2 Set[T]) bool {
 
 // Clone clones the Set.
 func (s *TreeSet[T]) Clone() Set[T] {
-	return &TreeSet[T]{tree: s.tree.Clone(), cmp: s.cmp}
+	return s.withTree(s.t().Clone())
 }
 
 // Elements returns a slice with all elements of the Set.
 func (s *TreeSet[T]) Elements() []T {
-	return s.tree.Slice()
+	return s.t().Slice()
 }
 
 // Iter returns an iterator over all elements of the Set.
 func (s *TreeSet[T]) Iter() iter.Seq[T] {
-	return s.tree.Iter()
+	return s.t().Iter()
 }
 
-// String returns a string representation of the Set.
+// String returns a string representation of the Set (see [ParseTreeSet]).
 func (s *TreeSet[T]) String() string {
-	sl := make([]string, 0, s.tree.Count())
-	s.tree.Each(func(elem T) {
-		sl = append(sl, fmt.Sprintf("%v", elem))
-	})
-	return fmt.Sprintf("TreeSet{%s}", strings.Join(sl, ", "))
+	tree := s.t()
+	return formatSet("TreeSet", tree.Count(), tree.Iter())
 }
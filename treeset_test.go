@@ -308,3 +308,172 @@ func TestStringTS(t *testing.T) {
 		t.Errorf("got %q, want %q", got, want)
 	}
 }
+
+func TestInPlaceTS(t *testing.T) {
+	var tests = []struct {
+		f    func(s *TreeSet[int], s2 ReadOnlySet[int]) int
+		n    int
+		want []int
+	}{
+		{(*TreeSet[int]).UnionWith, 1, []int{1, 2, 3}},
+		{(*TreeSet[int]).IntersectWith, 1, []int{2}},
+		{(*TreeSet[int]).DifferenceWith, 1, []int{1}},
+		{(*TreeSet[int]).SymDifferenceWith, 2, []int{1, 3}},
+	}
+	for i, test := range tests {
+		for j, s2 := range []ReadOnlySet[int]{NewTreeSet(2, 3), NewMapSet(2, 3)} {
+			s := NewTreeSet(1, 2)
+			if n := test.f(s, s2); n != test.n {
+				t.Errorf("%d/%d: got %d, want %d", i, j, n, test.n)
+			}
+			if got := s.Elements(); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%d/%d: got %v, want %v", i, j, got, test.want)
+			}
+		}
+	}
+}
+
+func TestInPlaceSelfTS(t *testing.T) {
+	s := NewTreeSet(1, 2)
+	if n := s.SymDifferenceWith(s); n != 2 || !s.IsEmpty() {
+		t.Errorf("got %d and %v, want 2 and empty set", n, s)
+	}
+}
+
+func TestCardinalitiesTS(t *testing.T) {
+	var tests = []struct {
+		s1                 *TreeSet[int]
+		s2                 ReadOnlySet[int]
+		inter, union, diff int
+		disjoint           bool
+	}{
+		{NewTreeSet[int](), NewTreeSet[int](), 0, 0, 0, true},
+		{NewTreeSet(1, 2), NewTreeSet[int](), 0, 2, 2, true},
+		{NewTreeSet(1, 2), NewTreeSet(3), 0, 3, 2, true},
+		{NewTreeSet(1, 3, 5, 7), NewTreeSet(0, 3, 4, 7, 9), 2, 7, 2, false},
+		{NewTreeSet(1, 2), NewMapSet(2, 3, 4), 1, 4, 1, false},
+		{NewTreeSet(1, 2), ReadOnly[int](NewTreeSet(1, 2)), 2, 2, 0, false},
+		{NewTreeSet(1, 2), NewTreeSetFunc(func(a, b int) int { return b - a }, 2, 1), 2, 2, 0, false},
+	}
+	for i, test := range tests {
+		if n := test.s1.IntersectionCardinality(test.s2); n != test.inter {
+			t.Errorf("%d: got intersection %d, want %d", i, n, test.inter)
+		}
+		if n := test.s1.UnionCardinality(test.s2); n != test.union {
+			t.Errorf("%d: got union %d, want %d", i, n, test.union)
+		}
+		if n := test.s1.DifferenceCardinality(test.s2); n != test.diff {
+			t.Errorf("%d: got difference %d, want %d", i, n, test.diff)
+		}
+		if b := test.s1.IsDisjoint(test.s2); b != test.disjoint {
+			t.Errorf("%d: got disjoint %t, want %t", i, b, test.disjoint)
+		}
+		if b := test.s1.Overlaps(test.s2); b == test.disjoint {
+			t.Errorf("%d: got overlaps %t, want %t", i, b, !test.disjoint)
+		}
+	}
+}
+
+func TestIsSupersetTS(t *testing.T) {
+	var tests = []struct {
+		s1     *TreeSet[int]
+		s2     ReadOnlySet[int]
+		super  bool
+		proper bool
+	}{
+		{NewTreeSet[int](), NewTreeSet[int](), true, false},
+		{NewTreeSet(1), NewTreeSet[int](), true, true},
+		{NewTreeSet(1, 2), NewMapSet(1), true, true},
+		{NewTreeSet(1, 2), NewMapSet(1, 2), true, false},
+		{NewTreeSet(1), NewTreeSet(1, 2), false, false},
+		{NewTreeSet(1, 3, 4), NewTreeSet(1, 2), false, false},
+	}
+	for i, test := range tests {
+		if got := test.s1.IsSuperset(test.s2); got != test.super {
+			t.Errorf("%d: got %t, want %t", i, got, test.super)
+		}
+		if got := test.s1.IsProperSuperset(test.s2); got != test.proper {
+			t.Errorf("%d: got %t, want %t", i, got, test.proper)
+		}
+	}
+}
+
+func TestZeroValueTS(t *testing.T) {
+	type id int
+	var s TreeSet[id]
+	if !s.IsEmpty() || s.Contains(1) {
+		t.Error("zero value is not empty")
+	}
+	s.Update(3, 1, 2)
+	if got, want := s.Elements(), []id{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	var s2 TreeSet[string]
+	if n := s2.UnionWith(NewTreeSet("b", "a")); n != 2 {
+		t.Errorf("got %d, want 2", n)
+	}
+	if n := s2.IntersectionCardinality(NewTreeSet("a", "c")); n != 1 {
+		t.Errorf("got %d, want 1", n)
+	}
+}
+
+func TestZeroValueUnorderedTS(t *testing.T) {
+	var s TreeSet[struct{}]
+	if !s.IsEmpty() {
+		t.Error("zero value is not empty")
+	}
+	defer func() {
+		if r := recover(); r != errUnordered {
+			t.Errorf("got %v, want %v", r, errUnordered)
+		}
+	}()
+	s.Add(struct{}{})
+}
+
+func TestNilReceiverTS(t *testing.T) {
+	var s *TreeSet[int]
+	if !s.IsEmpty() || s.Contains(1) || s.Cardinality() != 0 || s.Remove(1) {
+		t.Error("nil set is not empty")
+	}
+	if got := s.String(); got != "TreeSet{}" {
+		t.Errorf("got %q, want %q", got, "TreeSet{}")
+	}
+	if got := s.Union(NewMapSet(2, 1)).Elements(); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("got %v, want [1 2]", got)
+	}
+	if !NewTreeSet[int]().Equal(s) || NewTreeSet(1).Overlaps(s) {
+		t.Error("nil set is not empty")
+	}
+}
+
+func TestClearPopAnyTS(t *testing.T) {
+	s := NewTreeSet(3, 1, 2)
+	if elem, ok := s.Any(); elem != 1 || !ok {
+		t.Errorf("got %d and %t, want 1 and true", elem, ok)
+	}
+	got := []int{}
+	for {
+		elem, ok := s.Pop()
+		if !ok {
+			break
+		}
+		got = append(got, elem)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	s.Update(1, 2)
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Errorf("got %v, want empty set", s)
+	}
+	s.Add(1)
+	if !s.Contains(1) {
+		t.Error("got false, want true")
+	}
+	var s2 TreeSet[int]
+	s2.Clear()
+	if _, ok := s2.Pop(); ok {
+		t.Error("got true, want false")
+	}
+}
@@ -0,0 +1,134 @@
+package set
+
+import (
+	"context"
+	"sync"
+)
+
+// UniqueQueue is a FIFO queue in which every element is queued at most once.
+// It is safe for concurrent use.
+//
+// If processing is enabled, an element that was popped is in the processing
+// state until Done is called for it. Pushing such an element again does not
+// queue it immediately; it is queued when Done is called.
+type UniqueQueue[T comparable] struct {
+	mu         sync.Mutex
+	queue      []T
+	dirty      *MapSet[T]
+	processing *MapSet[T]
+	bc         broadcast
+}
+
+// NewUniqueQueue returns a new UniqueQueue with the given elements.
+// If processing is true, popped elements are not queued again until Done is called.
+func NewUniqueQueue[T comparable](processing bool, elems ...T) *UniqueQueue[T] {
+	q := &UniqueQueue[T]{dirty: NewMapSet[T]()}
+	if processing {
+		q.processing = NewMapSet[T]()
+	}
+	for _, elem := range elems {
+		q.push(elem)
+	}
+	return q
+}
+
+// Push appends elem to the queue.
+// Returns true if it was queued or is pending re-queue (see [UniqueQueue.Contains]),
+// false if it already was.
+func (q *UniqueQueue[T]) Push(elem T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.push(elem)
+}
+
+func (q *UniqueQueue[T]) push(elem T) bool {
+	if !q.dirty.Add(elem) {
+		return false
+	}
+	if q.processing == nil || !q.processing.Contains(elem) {
+		q.queue = append(q.queue, elem)
+		q.bc.notify()
+	}
+	return true
+}
+
+// Pop removes and returns the first element of the queue.
+// Returns false if the queue is empty.
+func (q *UniqueQueue[T]) Pop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pop()
+}
+
+func (q *UniqueQueue[T]) pop() (T, bool) {
+	var zero T
+	if len(q.queue) == 0 {
+		return zero, false
+	}
+	elem := q.queue[0]
+	q.queue[0] = zero
+	q.queue = q.queue[1:]
+	q.dirty.Remove(elem)
+	if q.processing != nil {
+		q.processing.Add(elem)
+	}
+	return elem, true
+}
+
+// PopWait removes and returns the first element of the queue.
+// If the queue is empty it blocks until an element is pushed or ctx is done.
+// Returns ctx.Err() if ctx is done before an element was available.
+func (q *UniqueQueue[T]) PopWait(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if elem, ok := q.pop(); ok {
+			q.mu.Unlock()
+			return elem, nil
+		}
+		ch := q.bc.wait()
+		q.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-ch:
+		}
+	}
+}
+
+// Done marks elem as processed. If it was pushed again while it was
+// processed, it is appended to the queue.
+// Done has no effect if processing is not enabled.
+func (q *UniqueQueue[T]) Done(elem T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.processing == nil || !q.processing.Remove(elem) {
+		return
+	}
+	if q.dirty.Contains(elem) {
+		q.queue = append(q.queue, elem)
+		q.bc.notify()
+	}
+}
+
+// Len returns the number of elements in the queue.
+func (q *UniqueQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.queue)
+}
+
+// Contains reports whether the element is queued or pending re-queue, i.e.
+// it was pushed while it was processed and is queued when Done is called.
+func (q *UniqueQueue[T]) Contains(elem T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.dirty.Contains(elem)
+}
+
+// IsProcessing reports whether the element was popped and Done was not yet called.
+func (q *UniqueQueue[T]) IsProcessing(elem T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.processing != nil && q.processing.Contains(elem)
+}
@@ -0,0 +1,91 @@
+package set
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func popAll[T comparable](q *UniqueQueue[T]) []T {
+	result := []T{}
+	for {
+		elem, ok := q.Pop()
+		if !ok {
+			return result
+		}
+		result = append(result, elem)
+	}
+}
+
+func TestUniqueQueue(t *testing.T) {
+	q := NewUniqueQueue(false, 1, 2, 1)
+	if q.Push(2) {
+		t.Error("got true, want false")
+	}
+	if !q.Push(3) {
+		t.Error("got false, want true")
+	}
+	if n := q.Len(); n != 3 {
+		t.Errorf("got %d, want 3", n)
+	}
+	if !q.Contains(3) {
+		t.Error("got false, want true")
+	}
+	want := []int{1, 2, 3}
+	if got := popAll(q); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if q.Contains(3) {
+		t.Error("got true, want false")
+	}
+	if !q.Push(1) {
+		t.Error("got false, want true")
+	}
+}
+
+func TestUniqueQueueProcessing(t *testing.T) {
+	q := NewUniqueQueue(true, 1, 2)
+	if elem, _ := q.Pop(); elem != 1 {
+		t.Errorf("got %d, want 1", elem)
+	}
+	if !q.IsProcessing(1) {
+		t.Error("got false, want true")
+	}
+	if !q.Push(1) {
+		t.Error("got false, want true")
+	}
+	if q.Push(1) {
+		t.Error("got true, want false")
+	}
+	if n := q.Len(); n != 1 || !q.Contains(1) {
+		t.Errorf("got %d and %t, want 1 and true", n, q.Contains(1))
+	}
+	q.Done(1)
+	if q.IsProcessing(1) {
+		t.Error("got true, want false")
+	}
+	want := []int{2, 1}
+	if got := popAll(q); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestUniqueQueuePopWait(t *testing.T) {
+	q := NewUniqueQueue[int](false)
+	done := make(chan int)
+	go func() {
+		elem, _ := q.PopWait(context.Background())
+		done <- elem
+	}()
+	q.Push(1)
+	if elem := <-done; elem != 1 {
+		t.Errorf("got %d, want 1", elem)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.PopWait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
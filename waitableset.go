@@ -0,0 +1,148 @@
+package set
+
+import (
+	"context"
+	"sync"
+)
+
+// broadcast wakes up all goroutines waiting for a change.
+// The zero value is ready to use; it must be guarded by the owner's mutex.
+type broadcast struct {
+	ch chan struct{}
+}
+
+// wait returns a channel that is closed on the next call to notify.
+func (b *broadcast) wait() <-chan struct{} {
+	if b.ch == nil {
+		b.ch = make(chan struct{})
+	}
+	return b.ch
+}
+
+// notify wakes up all current waiters.
+func (b *broadcast) notify() {
+	if b.ch != nil {
+		close(b.ch)
+		b.ch = nil
+	}
+}
+
+// WaitableSet wraps a [Set] and allows goroutines to wait for changes.
+// It is safe for concurrent use.
+type WaitableSet[T any] struct {
+	mu  sync.Mutex
+	set Set[T]
+	bc  broadcast
+}
+
+// NewWaitableSet returns a new WaitableSet that wraps s.
+// After this call s must only be accessed through the WaitableSet.
+func NewWaitableSet[T any](s Set[T]) *WaitableSet[T] {
+	return &WaitableSet[T]{set: s}
+}
+
+// Contains reports whether the element is in the Set.
+func (s *WaitableSet[T]) Contains(elem T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Contains(elem)
+}
+
+// Add adds an element to the Set.
+// Returns true if it was added, false if it already was in the set.
+func (s *WaitableSet[T]) Add(elem T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set.Add(elem) {
+		s.bc.notify()
+		return true
+	}
+	return false
+}
+
+// Update updates the Set with elems.
+func (s *WaitableSet[T]) Update(elems ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Update(elems...)
+	s.bc.notify()
+}
+
+// Remove removes an element from the Set.
+// Returns true if it was in the set, false otherwise.
+func (s *WaitableSet[T]) Remove(elem T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set.Remove(elem) {
+		s.bc.notify()
+		return true
+	}
+	return false
+}
+
+// IsEmpty returns true if Set is an empty set.
+func (s *WaitableSet[T]) IsEmpty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.IsEmpty()
+}
+
+// Cardinality returns the number of elements in the Set.
+func (s *WaitableSet[T]) Cardinality() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Cardinality()
+}
+
+// Snapshot returns a clone of the wrapped Set.
+func (s *WaitableSet[T]) Snapshot() Set[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Clone()
+}
+
+// WaitFor blocks until elem is in the Set or ctx is done.
+// Returns ctx.Err() if ctx is done before the element was added.
+func (s *WaitableSet[T]) WaitFor(ctx context.Context, elem T) error {
+	return s.WaitUntil(ctx, func(s ReadOnlySet[T]) bool {
+		return s.Contains(elem)
+	})
+}
+
+// WaitGone blocks until elem is not in the Set or ctx is done.
+// Returns ctx.Err() if ctx is done before the element was removed.
+func (s *WaitableSet[T]) WaitGone(ctx context.Context, elem T) error {
+	return s.WaitUntil(ctx, func(s ReadOnlySet[T]) bool {
+		return !s.Contains(elem)
+	})
+}
+
+// WaitUntil blocks until pred returns true or ctx is done.
+// Returns ctx.Err() if ctx is done before pred returned true.
+// Function pred is called with the lock held after each change of the Set;
+// it gets a read-only view of the Set and must not retain it.
+func (s *WaitableSet[T]) WaitUntil(ctx context.Context, pred func(ReadOnlySet[T]) bool) error {
+	view := ReadOnly(s.set)
+	for {
+		ch := s.check(pred, view)
+		if ch == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+	}
+}
+
+// check calls pred with the lock held. It returns nil if pred returned true,
+// otherwise a channel that is closed after the next change of the Set.
+func (s *WaitableSet[T]) check(pred func(ReadOnlySet[T]) bool, view ReadOnlySet[T]) <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pred(view) {
+		return nil
+	}
+	return s.bc.wait()
+}
@@ -0,0 +1,89 @@
+package set
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitFor(t *testing.T) {
+	s := NewWaitableSet[int](NewMapSet[int]())
+	done := make(chan error)
+	go func() {
+		done <- s.WaitFor(context.Background(), 1)
+	}()
+	s.Add(2)
+	s.Add(1)
+	if err := <-done; err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if err := s.WaitFor(context.Background(), 1); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+}
+
+func TestWaitGone(t *testing.T) {
+	s := NewWaitableSet[int](NewTreeSet(1, 2))
+	done := make(chan error)
+	go func() {
+		done <- s.WaitGone(context.Background(), 1)
+	}()
+	s.Remove(2)
+	s.Remove(1)
+	if err := <-done; err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+}
+
+func TestWaitUntil(t *testing.T) {
+	s := NewWaitableSet[int](NewMapSet[int]())
+	done := make(chan error)
+	go func() {
+		done <- s.WaitUntil(context.Background(), func(s ReadOnlySet[int]) bool {
+			return s.Cardinality() == 3
+		})
+	}()
+	s.Update(1, 2)
+	s.Add(3)
+	if err := <-done; err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+}
+
+func TestWaitUntilReadOnly(t *testing.T) {
+	s := NewWaitableSet[int](NewMapSet(1))
+	err := s.WaitUntil(context.Background(), func(s ReadOnlySet[int]) bool {
+		_, ok := s.(Set[int])
+		return !ok
+	})
+	if err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+}
+
+func TestWaitCancel(t *testing.T) {
+	s := NewWaitableSet[int](NewMapSet[int]())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.WaitFor(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWaitUntilPanic(t *testing.T) {
+	s := NewWaitableSet[int](NewMapSet[int]())
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("got no panic")
+			}
+		}()
+		s.WaitUntil(context.Background(), func(ReadOnlySet[int]) bool {
+			panic("pred")
+		})
+	}()
+	if !s.Add(1) {
+		t.Error("got false, want true")
+	}
+}